@@ -1,19 +1,28 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 
-	"github.com/yourname/commitgen/internal/config"
-	"github.com/yourname/commitgen/internal/diff"
-	"github.com/yourname/commitgen/internal/hook"
-	"github.com/yourname/commitgen/internal/llm"
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/diff"
+	"github.com/Harri200191/gitmind/internal/hook"
+	"github.com/Harri200191/gitmind/internal/llm"
 )
 
+// llmContext returns a context canceled on SIGINT, so a slow Generate call
+// (an LLM streaming over HTTP) can be interrupted instead of running to
+// completion no matter what the user does.
+func llmContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
 const version = "0.1.0"
 
 func main() {
@@ -59,7 +68,8 @@ func cmdInstallHook(args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := hook.Install(repoRoot); err != nil {
+	cfg := config.Load()
+	if err := hook.Install(repoRoot, cfg); err != nil {
 		log.Fatal(err)
 	}
 	fmt.Println("Installed prepare-commit-msg hook.")
@@ -78,7 +88,9 @@ func cmdUninstallHook(args []string) {
 
 func cmdDoctor() {
 	cfg := config.Load()
-	ok, info := llm.Doctor(cfg)
+	ctx, cancel := llmContext()
+	defer cancel()
+	ok, info := llm.Doctor(ctx, cfg)
 	if ok {
 		fmt.Println("LLM ready:", info)
 	} else {
@@ -105,7 +117,9 @@ func cmdGenerate(args []string) {
 	}
 
 	cfg := config.Load()
-	message, err := llm.Generate(cfg, d)
+	ctx, cancel := llmContext()
+	defer cancel()
+	message, err := llm.Generate(ctx, cfg, d)
 	if err != nil {
 		// fall back to heuristic
 		message = diff.HeuristicMessage(d, cfg)
@@ -1,22 +1,100 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
-
-	"github.com/yourname/commitgen/internal/config"
-	"github.com/yourname/commitgen/internal/diff"
-	"github.com/yourname/commitgen/internal/hook"
-	"github.com/yourname/commitgen/internal/llm"
-	"github.com/yourname/commitgen/internal/security"
-	"github.com/yourname/commitgen/internal/splitter"
-	"github.com/yourname/commitgen/internal/testgen"
+	"sort"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/diff"
+	"github.com/Harri200191/gitmind/internal/gitexec"
+	"github.com/Harri200191/gitmind/internal/gitx"
+	"github.com/Harri200191/gitmind/internal/hook"
+	"github.com/Harri200191/gitmind/internal/i18n"
+	"github.com/Harri200191/gitmind/internal/llm"
+	"github.com/Harri200191/gitmind/internal/patch"
+	"github.com/Harri200191/gitmind/internal/repo"
+	"github.com/Harri200191/gitmind/internal/security"
+	"github.com/Harri200191/gitmind/internal/splitter"
+	"github.com/Harri200191/gitmind/internal/testgen"
+	"github.com/Harri200191/gitmind/internal/tui"
 )
 
+// llmContext returns a context canceled on SIGINT, so a slow Generate call
+// (an LLM streaming over HTTP) can be interrupted instead of running to
+// completion no matter what the user does.
+func llmContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// baselinePath returns cfg.Security.BaselinePath, falling back to
+// security.DefaultBaselinePath when the repo hasn't configured one.
+func baselinePath(cfg config.Config) string {
+	if cfg.Security.BaselinePath != "" {
+		return cfg.Security.BaselinePath
+	}
+	return security.DefaultBaselinePath
+}
+
+// ignorePath returns cfg.Security.IgnorePath, falling back to
+// security.DefaultIgnorePath when the repo hasn't configured one.
+func ignorePath(cfg config.Config) string {
+	if cfg.Security.IgnorePath != "" {
+		return cfg.Security.IgnorePath
+	}
+	return security.DefaultIgnorePath
+}
+
+// sarifPath returns cfg.Security.SarifPath, falling back to
+// security.DefaultSarifPath when the repo hasn't configured one.
+func sarifPath(cfg config.Config) string {
+	if cfg.Security.SarifPath != "" {
+		return cfg.Security.SarifPath
+	}
+	return security.DefaultSarifPath
+}
+
+// parseStatusFilter splits a comma-separated --status flag value into its
+// component statuses, or returns nil for an empty flag (meaning "no
+// filter" to security.FilterByStatus).
+func parseStatusFilter(flagValue string) []string {
+	if flagValue == "" {
+		return nil
+	}
+	var statuses []string
+	for _, s := range strings.Split(flagValue, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// countBySeverity tallies findings the same way Summary does, for
+// re-displaying totals after a --status filter narrows report.Findings.
+func countBySeverity(findings []security.Finding) (total, high, medium, low int) {
+	for _, f := range findings {
+		total++
+		switch f.Severity {
+		case "high":
+			high++
+		case "medium":
+			medium++
+		case "low":
+			low++
+		}
+	}
+	return total, high, medium, low
+}
+
 const version = "0.1.0"
 
 func main() {
@@ -39,10 +117,18 @@ func main() {
 		cmdDoctor()
 	case "multi-commit":
 		cmdMultiCommit(os.Args[2:])
+	case "stage-hunks":
+		cmdStageHunks(os.Args[2:])
+	case "summarize":
+		cmdSummarize(os.Args[2:])
 	case "suggest-tests":
 		cmdSuggestTests(os.Args[2:])
 	case "security-check":
 		cmdSecurityCheck(os.Args[2:])
+	case "security":
+		cmdSecurity(os.Args[2:])
+	case "validate-message":
+		cmdValidateMessage(os.Args[2:])
 	case "version", "-v", "--version":
 		fmt.Println(version)
 	default:
@@ -51,19 +137,35 @@ func main() {
 	}
 }
 
+// usageLines is the command table printed after the "Usage:" header, kept
+// as one catalog entry per line so a translation can replace a line's
+// description without having to also reproduce gitmind's own column
+// alignment in Go source.
+var usageLines = []string{
+	"  gitmind install-hook              Install prepare-commit-msg hook in current repo",
+	"  gitmind uninstall-hook            Remove hook from current repo",
+	"  gitmind generate -f <path>        Generate a message into commit-msg file (hook calls this)",
+	"  gitmind generate --range <a>..<b> Generate a message for a diff over that range, printed or written with -f",
+	"  gitmind generate --commit <sha>   Regenerate HEAD's message and rewrite it with git commit --amend",
+	"  gitmind summarize <a>..<b>        Changelog-style multi-paragraph summary of a range, for release notes",
+	"  gitmind validate-message -f <path> Validate a commit message against Style (commit-msg hook calls this)",
+	"  gitmind multi-commit              Analyze and split staged changes into multiple commits",
+	"  gitmind stage-hunks               Interactively restage the index at hunk or line granularity",
+	"  gitmind suggest-tests             Generate unit tests for changed functions",
+	"  gitmind security-check            Run security analysis on staged changes",
+	"  gitmind security baseline update  Snapshot current findings as accepted baseline",
+	"  gitmind security export-sarif     Write staged-diff findings as a SARIF 2.1.0 log",
+	"  gitmind security import-sarif     Merge an external SARIF log into the staged-diff findings",
+	"  gitmind doctor                    Check model/config availability",
+	"  gitmind version                   Print version",
+}
+
 func usage() {
-	fmt.Fprintf(os.Stderr, `gitmind %s
-
-Usage:
-  gitmind install-hook              Install prepare-commit-msg hook in current repo
-  gitmind uninstall-hook            Remove hook from current repo
-  gitmind generate -f <path>        Generate a message into commit-msg file (hook calls this)
-  gitmind multi-commit              Analyze and split staged changes into multiple commits
-  gitmind suggest-tests             Generate unit tests for changed functions
-  gitmind security-check            Run security analysis on staged changes
-  gitmind doctor                    Check model/config availability
-  gitmind version                   Print version
-`, version)
+	fmt.Fprintf(os.Stderr, i18n.T("gitmind %s")+"\n\n", version)
+	fmt.Fprintln(os.Stderr, i18n.T("Usage:"))
+	for _, line := range usageLines {
+		fmt.Fprintln(os.Stderr, i18n.T(line))
+	}
 }
 
 func cmdInstallHook(args []string) {
@@ -71,10 +173,10 @@ func cmdInstallHook(args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := hook.Install(repoRoot); err != nil {
+	cfg := config.Load()
+	if err := hook.Install(repoRoot, cfg); err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("Installed prepare-commit-msg hook.")
 }
 
 func cmdUninstallHook(args []string) {
@@ -85,50 +187,121 @@ func cmdUninstallHook(args []string) {
 	if err := hook.Uninstall(repoRoot); err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("Removed prepare-commit-msg hook.")
+	fmt.Println("Removed gitmind-managed hooks.")
 }
 
 func cmdDoctor() {
 	cfg := config.Load()
-	ok, info := llm.Doctor(cfg)
+	ctx, cancel := llmContext()
+	defer cancel()
+	ok, info := llm.Doctor(ctx, cfg)
 	if ok {
-		fmt.Println("LLM ready:", info)
+		fmt.Println(i18n.T("LLM ready:"), info)
+	} else {
+		fmt.Println(i18n.T("LLM not ready:"), info)
+	}
+
+	fmt.Println("\n" + i18n.T("Provider status:"))
+	results := llm.DoctorAll(ctx, cfg)
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %s: %s\n", name, results[name])
+	}
+
+	fmt.Println("\n" + i18n.T("Repo backend:"))
+	if reader, err := repo.New(cfg); err != nil {
+		fmt.Println(i18n.T("  not ready:"), err)
+	} else if root, err := reader.Root(); err != nil {
+		fmt.Println(i18n.T("  not ready:"), err)
 	} else {
-		fmt.Println("LLM not ready:", info)
+		fmt.Printf(i18n.T("  %s ready (root %s)\n"), cfg.Repo.Backend, root)
+	}
+
+	fmt.Println("\n" + i18n.T("Multi-commit UI:"))
+	fmt.Println(" ", tui.PanelStatus)
+
+	fmt.Println("\n" + i18n.T("Deferred library integrations:"))
+	for _, d := range deferredLibraries {
+		fmt.Printf(i18n.T("  %s not vendored -- %s\n"), d.Library, d.Affects)
 	}
+	fmt.Println(i18n.T("  see LIMITATIONS.md for the full per-request accounting"))
+}
+
+// deferredLibraries lists every dependency go.mod only comments out (see
+// the "Note:" blocks there), and which packages ship a hand-rolled
+// stand-in instead, so `gitmind doctor` says so out loud instead of this
+// only being discoverable by reading source comments. Whether or not one
+// of these ever gets vendored is a standing decision, not an oversight --
+// this list, and LIMITATIONS.md's longer per-request version of it, is
+// how that decision stays visible.
+var deferredLibraries = []struct {
+	Library string
+	Affects string
+}{
+	{"github.com/go-git/go-git/v5", "internal/splitter (patch.go, gitattributes.go), internal/gitx, internal/repo, internal/llm (fewshot.go) use exec.Command/regexp stand-ins"},
+	{"github.com/gdamore/tcell/v2 or gocui", "internal/ui drives a line-oriented command loop instead of a three-pane panel; internal/tui delegates to it unchanged"},
 }
 
 func cmdGenerate(args []string) {
 	fs := flag.NewFlagSet("generate", flag.ExitOnError)
 	file := fs.String("f", "", "path to commit-msg file (provided by git)")
 	suggestTests := fs.Bool("suggest-tests", false, "generate unit tests for changed functions")
+	profile := fs.String("profile", "", "named config profile to apply on top of .gitmind.yaml (default: $GITMIND_PROFILE)")
+	rangeSpec := fs.String("range", "", "<rev>..<rev> to generate a message for instead of the staged diff")
+	commitSHA := fs.String("commit", "", "existing HEAD commit to regenerate the message for via git commit --amend")
 	_ = fs.Parse(args)
-	if *file == "" {
-		log.Fatal("-f commit message file is required")
+
+	if *commitSHA != "" {
+		cmdGenerateForCommit(*commitSHA, *profile)
+		return
+	}
+	if *rangeSpec == "" && *file == "" {
+		log.Fatal(i18n.T("-f commit message file is required"))
 	}
 
-	// Read staged diff
-	d, err := diff.Staged()
+	// Read the staged diff, or the range's diff when --range narrows this
+	// to something other than what's staged.
+	var d string
+	var err error
+	if *rangeSpec != "" {
+		d, err = diff.Range(*rangeSpec)
+	} else {
+		d, err = diff.Staged()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 	if d == "" {
+		if *rangeSpec != "" {
+			fmt.Println(i18n.T("No changes in that range"))
+			return
+		}
 		// nothing staged; don't clobber existing
 		os.Exit(0)
 	}
 
 	cfg := config.Load()
+	if *profile != "" {
+		cfg = config.LoadProfile(*profile)
+	}
+	ctx, cancel := llmContext()
+	defer cancel()
 
 	// Run security analysis
 	if cfg.Security.Enabled {
 		secAnalyzer := security.New(cfg)
-		secReport, err := secAnalyzer.AnalyzeDiff(d)
+		_ = secAnalyzer.LoadBaseline(baselinePath(cfg))
+		secReport, err := secAnalyzer.AnalyzeDiff(ctx, d)
 		if err == nil {
 			if secAnalyzer.ShouldBlockCommit(secReport) {
-				fmt.Fprintf(os.Stderr, "❌ Commit blocked due to high-severity security issues:\n")
+				fmt.Fprintln(os.Stderr, i18n.T("❌ Commit blocked due to high-severity security issues:"))
 				for _, finding := range secReport.Findings {
 					if finding.Severity == "high" {
-						fmt.Fprintf(os.Stderr, "  %s:%d - %s\n", finding.File, finding.Line, finding.Message)
+						fmt.Fprintf(os.Stderr, i18n.T("  %s:%d - %s\n"), finding.File, finding.Line, finding.Message)
 					}
 				}
 				os.Exit(1)
@@ -141,23 +314,33 @@ func cmdGenerate(args []string) {
 		mcm := splitter.NewMultiCommitManager(cfg)
 		proposals, err := mcm.ProcessStagedChanges()
 		if err == nil && len(proposals) > 1 {
-			fmt.Printf("💡 Detected %d logical changes. Use 'gitmind multi-commit' to split into separate commits\n", len(proposals))
+			fmt.Printf(i18n.T("💡 Detected %d logical changes. Use 'gitmind multi-commit' to split into separate commits\n"), len(proposals))
 		}
 	}
 
 	// Generate commit message
-	message, err := llm.Generate(cfg, d)
+	message, err := llm.Generate(ctx, cfg, d)
 	if err != nil {
 		// fall back to heuristic
 		message = diff.HeuristicMessage(d, cfg)
 	}
 
-	// Enhance message with security notes if enabled
+	// Enhance message with security notes if enabled, and leave a combined
+	// SARIF log behind for CI to ingest (gitmind security export-sarif
+	// covers the on-demand case; this keeps the file fresh on every commit
+	// without the caller having to remember to run it).
 	if cfg.Security.Enabled {
 		secAnalyzer := security.New(cfg)
-		secReport, err := secAnalyzer.AnalyzeDiff(d)
+		_ = secAnalyzer.LoadBaseline(baselinePath(cfg))
+		_ = secAnalyzer.LoadIgnoreFile(ignorePath(cfg))
+		secReport, err := secAnalyzer.AnalyzeDiff(ctx, d)
 		if err == nil {
 			message = secAnalyzer.GenerateCommitMessage(secReport, message)
+			if path := sarifPath(cfg); path != "-" {
+				if data, err := secAnalyzer.ExportSARIF(secReport.Findings); err == nil {
+					_ = os.WriteFile(path, data, 0644)
+				}
+			}
 		}
 	}
 
@@ -169,16 +352,158 @@ func cmdGenerate(args []string) {
 			testFiles, err := testGen.GenerateTests(functions)
 			if err == nil {
 				testGen.WriteTestFiles(testFiles)
-				message += "\n\n🧪 Generated unit tests for modified functions"
+				message += "\n\n" + i18n.T("🧪 Generated unit tests for modified functions")
 			}
 		}
 	}
 
+	if *file == "" {
+		fmt.Println(message)
+		return
+	}
 	if err := os.WriteFile(*file, []byte(message+"\n"), 0644); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// cmdGenerateForCommit regenerates HEAD's commit message from its own diff
+// and rewrites it via `git commit --amend`. git commit --amend can only
+// ever rewrite HEAD, so sha must resolve to it -- rewriting an older
+// commit's message needs an interactive rebase, which is out of scope
+// here.
+func cmdGenerateForCommit(sha, profile string) {
+	ctx, cancel := llmContext()
+	defer cancel()
+
+	resolved, err := gitexec.New("rev-parse").AddDynamic(sha).Run(ctx)
+	if err != nil {
+		log.Fatalf("failed to resolve %s: %v", sha, err)
+	}
+	head, err := gitexec.New("rev-parse").AddDynamic("HEAD").Run(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if strings.TrimSpace(resolved) != strings.TrimSpace(head) {
+		log.Fatalf("gitmind generate --commit only supports HEAD (rewriting %s's message needs an interactive rebase)", sha)
+	}
+
+	d, err := diff.Range(sha + "^.." + sha)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if d == "" {
+		fmt.Println("No changes in that commit")
+		return
+	}
+
+	cfg := config.Load()
+	if profile != "" {
+		cfg = config.LoadProfile(profile)
+	}
+
+	message, err := llm.Generate(ctx, cfg, d)
+	if err != nil {
+		message = diff.HeuristicMessage(d, cfg)
+	}
+
+	// -m's value is never parsed as a flag regardless of its content, so
+	// threading the generated message through AddFlags (rather than
+	// AddDynamic, which would reject one starting with "-") is safe here.
+	if _, err := gitexec.New("commit").AddFlags("--amend", "-m", message).Run(ctx); err != nil {
+		log.Fatalf("failed to amend commit message: %v", err)
+	}
+	fmt.Printf("Amended HEAD with:\n%s\n", message)
+}
+
+// cmdSummarize produces a changelog-style, multi-paragraph summary of
+// rangeSpec suitable for release notes: it splits the range's diff into
+// the same logical groups multi-commit would propose as separate commits,
+// then asks llm.Generate to describe each group's diff on its own,
+// joining the results into one summary instead of one giant prompt over
+// the whole range.
+func cmdSummarize(args []string) {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+	profile := fs.String("profile", "", "named config profile to apply on top of .gitmind.yaml (default: $GITMIND_PROFILE)")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		log.Fatal("usage: gitmind summarize <rev>..<rev>")
+	}
+	rangeSpec := fs.Arg(0)
+
+	d, err := diff.Range(rangeSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if d == "" {
+		fmt.Println("No changes in that range")
+		return
+	}
+
+	cfg := config.Load()
+	if *profile != "" {
+		cfg = config.LoadProfile(*profile)
+	}
+	ctx, cancel := llmContext()
+	defer cancel()
+
+	s := splitter.New(cfg)
+	changes, err := s.AnalyzeDiff(d)
+	if err != nil {
+		log.Fatal(err)
+	}
+	clusters, err := s.ClusterChanges(changes)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var paragraphs []string
+	for _, cluster := range clusters {
+		clusterDiff := splitter.ClusterDiff(cluster)
+		if strings.TrimSpace(clusterDiff) == "" {
+			continue
+		}
+		paragraph, err := llm.Generate(ctx, cfg, clusterDiff)
+		if err != nil {
+			paragraph = diff.HeuristicMessage(clusterDiff, cfg)
+		}
+		paragraphs = append(paragraphs, paragraph)
+	}
+	if len(paragraphs) == 0 {
+		fmt.Println("No logical changes found in that range")
+		return
+	}
+	fmt.Println(strings.Join(paragraphs, "\n\n"))
+}
+
+// cmdValidateMessage backs the commit-msg hook: it checks the message git
+// already wrote to *file against cfg.Style and exits non-zero, listing
+// every violation, if it doesn't comply.
+func cmdValidateMessage(args []string) {
+	fs := flag.NewFlagSet("validate-message", flag.ExitOnError)
+	file := fs.String("f", "", "path to commit-msg file (provided by git)")
+	_ = fs.Parse(args)
+	if *file == "" {
+		log.Fatal("-f commit message file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg := config.Load()
+	problems := hook.ValidateMessage(cfg, string(data))
+	if len(problems) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "Commit message doesn't meet style requirements:")
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  - %s\n", p)
+	}
+	os.Exit(1)
+}
+
 func findRepoRoot() (string, error) {
 	cwd, _ := os.Getwd()
 	for {
@@ -196,15 +521,31 @@ func findRepoRoot() (string, error) {
 func cmdMultiCommit(args []string) {
 	fs := flag.NewFlagSet("multi-commit", flag.ExitOnError)
 	interactive := fs.Bool("interactive", false, "interactive mode for editing proposals")
+	abort := fs.Bool("abort", false, "restore the repository from a multi-commit run that failed or was killed mid-split")
 	_ = fs.Parse(args)
 
 	cfg := config.Load()
 	if !cfg.MultiCommit.Enabled {
-		fmt.Println("Multi-commit is disabled in configuration")
+		fmt.Println(i18n.T("Multi-commit is disabled in configuration"))
 		os.Exit(1)
 	}
 
 	mcm := splitter.NewMultiCommitManager(cfg)
+	mcm.Editor = tui.Run
+	if cfg.Model.Enabled {
+		ctx, cancel := llmContext()
+		defer cancel()
+		mcm.MessageGenerator = func(d string) (string, error) {
+			return llm.Generate(ctx, cfg, d)
+		}
+	}
+
+	if *abort {
+		if err := mcm.Abort(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	if *interactive {
 		if err := mcm.InteractiveMultiCommit(); err != nil {
@@ -217,7 +558,7 @@ func cmdMultiCommit(args []string) {
 		}
 
 		if len(proposals) <= 1 {
-			fmt.Println("No multi-commit opportunities detected")
+			fmt.Println(i18n.T("No multi-commit opportunities detected"))
 			return
 		}
 
@@ -227,15 +568,101 @@ func cmdMultiCommit(args []string) {
 	}
 }
 
+// cmdStageHunks drives internal/patch's PatchManager directly against the
+// current staged diff, independent of multi-commit's clustering: it lists
+// every file's hunks and, for each one the user picks, which of its
+// added/removed lines to keep, then stages the resulting selection in one
+// `git apply --cached`. Unlike multi-commit it doesn't commit anything --
+// it only reshapes what's staged, the same way `git add -p` does.
+func cmdStageHunks(args []string) {
+	fs := flag.NewFlagSet("stage-hunks", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	repo := gitx.New()
+	diffText, err := repo.StagedDiff()
+	if err != nil {
+		log.Fatalf("failed to get staged diff: %v", err)
+	}
+	if strings.TrimSpace(diffText) == "" {
+		fmt.Println("Nothing staged")
+		return
+	}
+
+	mgr, err := patch.NewManager(diffText)
+	if err != nil {
+		log.Fatalf("failed to parse staged diff: %v", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	selected := false
+	for _, fd := range mgr.Patch().Files {
+		for hi, hunk := range fd.Hunks {
+			fmt.Printf("\n%s %s\n", fd.File(), hunk.Header)
+			if fd.IsBinary {
+				fmt.Println("  (binary file, staged whole)")
+				mgr.Select(patch.Selection{File: fd.File(), HunkIndex: hi})
+				selected = true
+				continue
+			}
+			for li, line := range hunk.Lines {
+				if line.Op == patch.Context {
+					continue
+				}
+				fmt.Printf("  [%d] %s: %s\n", li, line.Op.String(), line.Text)
+			}
+			fmt.Print("Stage this hunk? [y/N/lines]: ")
+			answer, _ := reader.ReadString('\n')
+			answer = strings.TrimSpace(answer)
+			switch {
+			case answer == "y" || answer == "Y":
+				mgr.Select(patch.Selection{File: fd.File(), HunkIndex: hi})
+				selected = true
+			case answer == "" || strings.EqualFold(answer, "n"):
+				// Leave unselected: stays staged as-is for a later run.
+			default:
+				kept := make(map[int]bool)
+				for _, field := range strings.Split(answer, ",") {
+					field = strings.TrimSpace(field)
+					if field == "" {
+						continue
+					}
+					var idx int
+					if _, err := fmt.Sscanf(field, "%d", &idx); err == nil {
+						kept[idx] = true
+					}
+				}
+				mgr.Select(patch.Selection{File: fd.File(), HunkIndex: hi, Lines: kept})
+				selected = true
+			}
+		}
+	}
+
+	if !selected {
+		fmt.Println("Nothing selected, index left unchanged")
+		return
+	}
+
+	// Back out everything currently staged, then restage only the
+	// selection -- stage-hunks reshapes the index, it doesn't add to it.
+	if err := repo.UnstageHunks(diffText); err != nil {
+		log.Fatalf("failed to reset the index: %v", err)
+	}
+	if err := mgr.Stage(); err != nil {
+		log.Fatalf("failed to stage selection: %v", err)
+	}
+	fmt.Println("Staged selection")
+}
+
 func cmdSuggestTests(args []string) {
 	fs := flag.NewFlagSet("suggest-tests", flag.ExitOnError)
 	outputDir := fs.String("output", ".", "output directory for test files")
 	autoStage := fs.Bool("stage", false, "automatically stage generated test files")
+	lastCommit := fs.Bool("last-commit", false, "analyze HEAD's diff instead of the staged diff (for a post-commit hook)")
 	_ = fs.Parse(args)
 
 	cfg := config.Load()
 	if !cfg.TestGeneration.Enabled {
-		fmt.Println("Test generation is disabled in configuration")
+		fmt.Println(i18n.T("Test generation is disabled in configuration"))
 		os.Exit(1)
 	}
 
@@ -247,13 +674,18 @@ func cmdSuggestTests(args []string) {
 		cfg.TestGeneration.AutoStage = true
 	}
 
-	// Get staged diff
-	d, err := diff.Staged()
+	var d string
+	var err error
+	if *lastCommit {
+		d, err = diff.LastCommit()
+	} else {
+		d, err = diff.Staged()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 	if d == "" {
-		fmt.Println("No staged changes found")
+		fmt.Println(i18n.T("No changes found"))
 		return
 	}
 
@@ -264,11 +696,11 @@ func cmdSuggestTests(args []string) {
 	}
 
 	if len(functions) == 0 {
-		fmt.Println("No testable functions found in staged changes")
+		fmt.Println(i18n.T("No testable functions found in staged changes"))
 		return
 	}
 
-	fmt.Printf("🔍 Found %d functions that can be tested:\n", len(functions))
+	fmt.Printf(i18n.T("🔍 Found %d functions that can be tested:\n"), len(functions))
 	for _, fn := range functions {
 		fmt.Printf("  - %s.%s\n", fn.Package, fn.Name)
 	}
@@ -282,18 +714,20 @@ func cmdSuggestTests(args []string) {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("✅ Generated test files for %d packages\n", len(testFiles))
+	fmt.Printf(i18n.T("✅ Generated test files for %d packages\n"), len(testFiles))
 }
 
 func cmdSecurityCheck(args []string) {
 	fs := flag.NewFlagSet("security-check", flag.ExitOnError)
 	blockOnHigh := fs.Bool("block", false, "block if high-severity issues found")
 	verbose := fs.Bool("verbose", false, "show detailed findings")
+	securityVerbose := fs.Bool("security-verbose", false, "stream per-analyzer progress (pending/running/done/failed/timed-out)")
+	status := fs.String("status", "", "comma-separated list of statuses to include, e.g. affected,fixed (default: all)")
 	_ = fs.Parse(args)
 
 	cfg := config.Load()
 	if !cfg.Security.Enabled {
-		fmt.Println("Security analysis is disabled in configuration")
+		fmt.Println(i18n.T("Security analysis is disabled in configuration"))
 		os.Exit(1)
 	}
 
@@ -301,6 +735,9 @@ func cmdSecurityCheck(args []string) {
 	if *blockOnHigh {
 		cfg.Security.BlockOnHigh = true
 	}
+	if *securityVerbose {
+		cfg.Security.Verbose = true
+	}
 
 	// Get staged diff
 	d, err := diff.Staged()
@@ -308,53 +745,232 @@ func cmdSecurityCheck(args []string) {
 		log.Fatal(err)
 	}
 	if d == "" {
-		fmt.Println("No staged changes found")
+		fmt.Println(i18n.T("No staged changes found"))
 		return
 	}
 
+	ctx, cancel := llmContext()
+	defer cancel()
+
 	secAnalyzer := security.New(cfg)
-	report, err := secAnalyzer.AnalyzeDiff(d)
+	if err := secAnalyzer.LoadBaseline(baselinePath(cfg)); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Warning: couldn't load security baseline: %v\n"), err)
+	}
+	if err := secAnalyzer.LoadIgnoreFile(ignorePath(cfg)); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Warning: couldn't load %s: %v\n"), ignorePath(cfg), err)
+	}
+	report, err := secAnalyzer.AnalyzeDiff(ctx, d)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	findings := security.FilterByStatus(report.Findings, parseStatusFilter(*status))
+
 	// Display summary
-	fmt.Printf("🔒 Security Analysis Results:\n")
-	fmt.Printf("  Total findings: %d\n", report.Summary.TotalFindings)
-	fmt.Printf("  High severity: %d\n", report.Summary.HighSeverity)
-	fmt.Printf("  Medium severity: %d\n", report.Summary.MediumSeverity)
-	fmt.Printf("  Low severity: %d\n", report.Summary.LowSeverity)
-
-	if *verbose && len(report.Findings) > 0 {
-		fmt.Println("\nDetailed Findings:")
-		for _, finding := range report.Findings {
-			fmt.Printf("  %s [%s] %s:%d - %s\n",
+	total, high, medium, low := countBySeverity(findings)
+	fmt.Println(i18n.T("🔒 Security Analysis Results:"))
+	fmt.Printf(i18n.T("  Total findings: %d\n"), total)
+	fmt.Printf(i18n.T("  High severity: %d\n"), high)
+	fmt.Printf(i18n.T("  Medium severity: %d\n"), medium)
+	fmt.Printf(i18n.T("  Low severity: %d\n"), low)
+	if len(report.Ignored) > 0 {
+		fmt.Printf(i18n.T("  Ignored: %d\n"), len(report.Ignored))
+	}
+
+	if *verbose && len(findings) > 0 {
+		fmt.Println("\n" + i18n.T("Detailed Findings:"))
+		for _, finding := range findings {
+			fmt.Printf(i18n.T("  %s [%s] %s:%d - %s\n"),
 				getSeverityEmoji(finding.Severity),
 				finding.Severity,
 				finding.File,
 				finding.Line,
 				finding.Message)
 			if finding.Suggestion != "" {
-				fmt.Printf("    💡 %s\n", finding.Suggestion)
+				fmt.Printf(i18n.T("    💡 %s\n"), finding.Suggestion)
 			}
 		}
 	}
 
 	if len(report.Suggestions) > 0 {
-		fmt.Println("\nGeneral Suggestions:")
+		fmt.Println("\n" + i18n.T("General Suggestions:"))
 		for _, suggestion := range report.Suggestions {
-			fmt.Printf("  • %s\n", suggestion)
+			fmt.Printf(i18n.T("  • %s\n"), suggestion)
 		}
 	}
 
 	if secAnalyzer.ShouldBlockCommit(report) {
-		fmt.Fprintf(os.Stderr, "\n❌ Commit should be blocked due to high-severity security issues\n")
+		fmt.Fprintln(os.Stderr, "\n"+i18n.T("❌ Commit should be blocked due to high-severity security issues"))
 		os.Exit(1)
 	}
 
 	if report.Summary.TotalFindings == 0 {
-		fmt.Println("\n✅ No security issues detected")
+		fmt.Println("\n" + i18n.T("✅ No security issues detected"))
+	}
+}
+
+// cmdSecurity dispatches the "gitmind security <subcommand>" family, kept
+// separate from the flat "security-check" command since it's expected to
+// grow more than one sub-action (baseline update today, baseline show /
+// prune later).
+func cmdSecurity(args []string) {
+	if len(args) >= 2 && args[0] == "baseline" && args[1] == "update" {
+		cmdSecurityBaselineUpdate(args[2:])
+		return
+	}
+	if len(args) >= 1 && args[0] == "export-sarif" {
+		cmdSecurityExportSARIF(args[1:])
+		return
+	}
+	if len(args) >= 1 && args[0] == "import-sarif" {
+		cmdSecurityImportSARIF(args[1:])
+		return
+	}
+	fmt.Fprintln(os.Stderr, "usage: gitmind security baseline update")
+	fmt.Fprintln(os.Stderr, "       gitmind security export-sarif [-o file]")
+	fmt.Fprintln(os.Stderr, "       gitmind security import-sarif <tool> <file>")
+	os.Exit(2)
+}
+
+// cmdSecurityExportSARIF analyzes the staged diff and writes the findings
+// as a SARIF 2.1.0 log, so they can be uploaded to GitHub code scanning or
+// viewed in any other SARIF-aware dashboard.
+func cmdSecurityExportSARIF(args []string) {
+	fs := flag.NewFlagSet("security export-sarif", flag.ExitOnError)
+	out := fs.String("o", "", "SARIF file to write (default: stdout)")
+	status := fs.String("status", "", "comma-separated list of statuses to include, e.g. affected,fixed (default: all)")
+	_ = fs.Parse(args)
+
+	cfg := config.Load()
+	if !cfg.Security.Enabled {
+		fmt.Println("Security analysis is disabled in configuration")
+		os.Exit(1)
+	}
+
+	d, err := diff.Staged()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if d == "" {
+		fmt.Println("No staged changes found")
+		return
+	}
+
+	ctx, cancel := llmContext()
+	defer cancel()
+
+	secAnalyzer := security.New(cfg)
+	_ = secAnalyzer.LoadBaseline(baselinePath(cfg))
+	_ = secAnalyzer.LoadIgnoreFile(ignorePath(cfg))
+	report, err := secAnalyzer.AnalyzeDiff(ctx, d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findings := security.FilterByStatus(report.Findings, parseStatusFilter(*status))
+	data, err := secAnalyzer.ExportSARIF(findings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Wrote SARIF to %s\n", *out)
+}
+
+// cmdSecurityImportSARIF merges a SARIF log from an external scanner (e.g.
+// a CI artifact) into the staged diff's own findings, so its Summary
+// reflects both without the caller having to reconcile two reports by hand.
+func cmdSecurityImportSARIF(args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gitmind security import-sarif <tool> <file>")
+		os.Exit(2)
+	}
+	tool, path := args[0], args[1]
+
+	cfg := config.Load()
+	if !cfg.Security.Enabled {
+		fmt.Println("Security analysis is disabled in configuration")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secAnalyzer := security.New(cfg)
+	_ = secAnalyzer.LoadIgnoreFile(ignorePath(cfg))
+	imported, err := secAnalyzer.ImportSARIF(data, tool)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report := &security.SecurityReport{}
+	if d, err := diff.Staged(); err == nil && d != "" {
+		ctx, cancel := llmContext()
+		defer cancel()
+		_ = secAnalyzer.LoadBaseline(baselinePath(cfg))
+		if r, err := secAnalyzer.AnalyzeDiff(ctx, d); err == nil {
+			report = r
+		}
+	}
+
+	merged := secAnalyzer.MergeFindings(report, imported)
+	fmt.Printf("🔒 Merged Security Analysis Results (staged diff + %s):\n", tool)
+	fmt.Printf("  Total findings: %d\n", merged.Summary.TotalFindings)
+	fmt.Printf("  High severity: %d\n", merged.Summary.HighSeverity)
+	fmt.Printf("  Medium severity: %d\n", merged.Summary.MediumSeverity)
+	fmt.Printf("  Low severity: %d\n", merged.Summary.LowSeverity)
+}
+
+// cmdSecurityBaselineUpdate snapshots the findings a whole-file scanner
+// reports on the current tree into a baseline file, so a legacy repo can
+// adopt gitmind's security analysis without every pre-existing issue
+// blocking the next commit.
+func cmdSecurityBaselineUpdate(args []string) {
+	fs := flag.NewFlagSet("security baseline update", flag.ExitOnError)
+	out := fs.String("o", "", "baseline file to write (default: security.baseline_path or "+security.DefaultBaselinePath+")")
+	_ = fs.Parse(args)
+
+	cfg := config.Load()
+	if !cfg.Security.Enabled {
+		fmt.Println("Security analysis is disabled in configuration")
+		os.Exit(1)
+	}
+
+	path := *out
+	if path == "" {
+		path = baselinePath(cfg)
+	}
+
+	d, err := diff.Staged()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if d == "" {
+		fmt.Println("No staged changes found")
+		return
+	}
+
+	ctx, cancel := llmContext()
+	defer cancel()
+
+	secAnalyzer := security.New(cfg)
+	report, err := secAnalyzer.AnalyzeDiff(ctx, d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := secAnalyzer.SaveBaseline(path, report.Findings); err != nil {
+		log.Fatal(err)
 	}
+	fmt.Printf("Wrote baseline to %s\n", path)
 }
 
 func getSeverityEmoji(severity string) string {
@@ -0,0 +1,141 @@
+// Command xstrings extracts translatable strings for internal/i18n: it
+// walks every .go file's AST looking for i18n.T(...) calls (and the bare
+// T(...) form, for a package that dot-imports i18n or aliases it) whose
+// first argument is a string literal, and writes one "msgid" per distinct
+// key to po/default.pot. A translator (or a future locale's
+// catalogs/<lang>.json) works from that list instead of grepping the Go
+// source for every call site by hand.
+//
+// Usage:
+//
+//	go run ./cmd/xstrings [root]
+//
+// root defaults to ".". Output always goes to po/default.pot under root.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	root := "."
+	if len(os.Args) > 1 {
+		root = os.Args[1]
+	}
+
+	keys, err := extractKeys(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outPath := filepath.Join(root, "po", "default.pot")
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := writePOT(outPath, keys); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %d msgid(s) to %s\n", len(keys), outPath)
+}
+
+// extractKeys walks root for .go files (skipping vendor/ and hidden
+// directories) and collects every distinct string literal passed as the
+// first argument to a call whose function name is "T" -- covering both
+// i18n.T(...) and a bare T(...) call, since a file inside internal/i18n
+// itself, or one that dot-imports it, won't write the package qualifier.
+func extractKeys(root string) ([]string, error) {
+	fset := token.NewFileSet()
+	seen := map[string]bool{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "vendor" || (strings.HasPrefix(d.Name(), ".") && d.Name() != ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if !isTCall(call.Fun) {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			seen[value] = true
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// isTCall reports whether fun is "T" or "<something>.T", covering
+// i18n.T(...), a differently-aliased import, and a bare T(...) from
+// inside internal/i18n itself.
+func isTCall(fun ast.Expr) bool {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name == "T"
+	case *ast.SelectorExpr:
+		return f.Sel.Name == "T"
+	default:
+		return false
+	}
+}
+
+// writePOT writes keys as a minimal gettext-style .pot: one "msgid"/
+// empty "msgstr" pair per key, msgid escaped the same way Go would quote
+// it, so a C-style .po-handling tool can read the file unchanged even
+// though nothing here depends on a real gettext library.
+func writePOT(path string, keys []string) error {
+	var b strings.Builder
+	b.WriteString("# Extracted by cmd/xstrings. Do not edit by hand; re-run the tool instead.\n")
+	b.WriteString(`msgid ""` + "\n" + `msgstr ""` + "\n" + `"Content-Type: text/plain; charset=UTF-8\n"` + "\n\n")
+	for _, key := range keys {
+		b.WriteString("msgid " + strconv.Quote(key) + "\n")
+		b.WriteString(`msgstr ""` + "\n\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
@@ -0,0 +1,63 @@
+// Package analyzer extracts structured symbols (functions, methods, types,
+// const/var groups) from a changed file and reports which ones a diff's
+// hunks actually touch. It replaces matching `^func\s+(\w+)` against raw
+// diff text, which misses methods, generic signatures, type declarations,
+// and every non-Go language.
+package analyzer
+
+import "fmt"
+
+// SymbolKind classifies what a Symbol refers to.
+type SymbolKind string
+
+const (
+	KindFunc   SymbolKind = "func"
+	KindMethod SymbolKind = "method"
+	KindType   SymbolKind = "type"
+	KindDecl   SymbolKind = "decl" // top-level const/var
+)
+
+// Symbol is a named declaration and the line range it spans in the
+// post-image of a file.
+type Symbol struct {
+	Kind SymbolKind
+	Name string
+	// Receiver is the bare type name a method is declared on (e.g.
+	// "Splitter" for `func (s *Splitter) Foo()`), empty for everything else.
+	Receiver string
+	// ReceiverPointer is true when Receiver was declared via a pointer
+	// receiver (`*Splitter`).
+	ReceiverPointer bool
+	Start           int
+	End             int
+}
+
+// String renders the symbol the way Go conventionally refers to it,
+// e.g. "(*Splitter).parseDiffHunks" for a method, "parseDiffHunks" otherwise.
+func (s Symbol) String() string {
+	if s.Kind != KindMethod || s.Receiver == "" {
+		return s.Name
+	}
+	if s.ReceiverPointer {
+		return fmt.Sprintf("(*%s).%s", s.Receiver, s.Name)
+	}
+	return fmt.Sprintf("(%s).%s", s.Receiver, s.Name)
+}
+
+// Hunk is the minimal line-range shape a LanguageAnalyzer needs from a diff
+// hunk. It deliberately doesn't depend on splitter.Hunk (splitter depends
+// on config; analyzer should stay a leaf package any caller can use).
+type Hunk struct {
+	StartLine int
+	EndLine   int
+}
+
+// LanguageAnalyzer parses a changed file's post-image and reports the
+// symbols whose line range overlaps at least one of hunks.
+type LanguageAnalyzer interface {
+	Symbols(file string, hunks []Hunk) ([]Symbol, error)
+}
+
+func rangesOverlap(aStart, aEnd, bStart, bEnd int) bool {
+	return aStart <= bEnd && bStart <= aEnd
+}
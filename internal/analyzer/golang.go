@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+)
+
+// GoAnalyzer parses the working-tree post-image of a changed Go file with
+// go/parser and intersects every FuncDecl/TypeSpec/top-level ValueSpec's
+// line range against the supplied hunks.
+type GoAnalyzer struct{}
+
+func (GoAnalyzer) Symbols(file string, hunks []Hunk) ([]Symbol, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Symbol
+	for _, decl := range astFile.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			all = append(all, funcSymbol(fset, d))
+		case *ast.GenDecl:
+			all = append(all, genDeclSymbols(fset, d)...)
+		}
+	}
+
+	var touched []Symbol
+	for _, sym := range all {
+		for _, h := range hunks {
+			if rangesOverlap(sym.Start, sym.End, h.StartLine, h.EndLine) {
+				touched = append(touched, sym)
+				break
+			}
+		}
+	}
+	return touched, nil
+}
+
+func funcSymbol(fset *token.FileSet, d *ast.FuncDecl) Symbol {
+	sym := Symbol{
+		Kind:  KindFunc,
+		Name:  d.Name.Name,
+		Start: fset.Position(d.Pos()).Line,
+		End:   fset.Position(d.End()).Line,
+	}
+	if d.Recv != nil && len(d.Recv.List) > 0 {
+		sym.Kind = KindMethod
+		sym.Receiver, sym.ReceiverPointer = receiverInfo(d.Recv.List[0].Type)
+	}
+	return sym
+}
+
+// genDeclSymbols covers `type` declarations (one Symbol per TypeSpec, so
+// `type ( A struct{}; B struct{} )` yields two symbols) and top-level
+// `const`/`var` groups (one Symbol per name, spanning the whole GenDecl
+// since individual ValueSpecs rarely carry their own doc comments).
+func genDeclSymbols(fset *token.FileSet, d *ast.GenDecl) []Symbol {
+	var out []Symbol
+	for _, spec := range d.Specs {
+		switch sp := spec.(type) {
+		case *ast.TypeSpec:
+			out = append(out, Symbol{
+				Kind:  KindType,
+				Name:  sp.Name.Name,
+				Start: fset.Position(sp.Pos()).Line,
+				End:   fset.Position(sp.End()).Line,
+			})
+		case *ast.ValueSpec:
+			start := fset.Position(d.Pos()).Line
+			end := fset.Position(d.End()).Line
+			for _, name := range sp.Names {
+				out = append(out, Symbol{Kind: KindDecl, Name: name.Name, Start: start, End: end})
+			}
+		}
+	}
+	return out
+}
+
+// receiverInfo unwraps a receiver type expression down to its bare type
+// name, reporting whether it was a pointer receiver. Generic receivers
+// (`func (r *Repo[T]) Foo()`) are unwrapped the same way.
+func receiverInfo(expr ast.Expr) (name string, pointer bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		name, _ = receiverInfo(t.X)
+		return name, true
+	case *ast.Ident:
+		return t.Name, false
+	case *ast.IndexExpr:
+		return receiverInfo(t.X)
+	case *ast.IndexListExpr:
+		return receiverInfo(t.X)
+	default:
+		return "", false
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -16,11 +17,36 @@ type Model struct {
 	Temp      float32 `yaml:"temperature"`
 	TopP      float32 `yaml:"top_p"`
 	MaxTokens int     `yaml:"max_tokens"`
+	// BaseURL overrides the provider's default API endpoint. Used to point
+	// the "openai" provider at an OpenAI-compatible server (vLLM,
+	// llama.cpp's server mode, LM Studio, ...) instead of api.openai.com.
+	BaseURL string `yaml:"base_url"`
 }
 
 type Prompt struct {
-	Preface string `yaml:"preface"`
-	Rules   string `yaml:"rules"`
+	Preface string  `yaml:"preface"`
+	Rules   string  `yaml:"rules"`
+	FewShot FewShot `yaml:"few_shot"`
+}
+
+// FewShot controls whether buildPrompt pulls prior "diff -> commit message"
+// pairs from the repo's own history to show the model the project's voice,
+// instead of relying solely on hand-written Rules.
+type FewShot struct {
+	Enabled bool `yaml:"enabled"`
+	// Count is the maximum number of examples to inject.
+	Count int `yaml:"count"`
+	// MaxDiffBytes truncates each example's diff excerpt so a handful of
+	// large historical commits don't blow the prompt budget.
+	MaxDiffBytes int `yaml:"max_diff_bytes"`
+	// PathFilter restricts history search to these pathspecs (e.g.
+	// "internal/splitter/*.go") instead of the files touched by the
+	// current diff, so a change to one package pulls prior commits about
+	// that package rather than unrelated history.
+	PathFilter []string `yaml:"path_filter"`
+	// MinMessageLen drops commits whose subject is shorter than this many
+	// characters (e.g. "wip", "fix") as poor examples of the project's voice.
+	MinMessageLen int `yaml:"min_message_len"`
 }
 
 type MultiCommit struct {
@@ -28,6 +54,20 @@ type MultiCommit struct {
 	MaxClusters         int     `yaml:"max_clusters"`
 	SimilarityThreshold float64 `yaml:"similarity_threshold"`
 	PromptUser          bool    `yaml:"prompt_user"`
+	// RespectGitAttributes tags each change with the .gitattributes
+	// entries that apply to it (linguist-generated, linguist-vendored,
+	// binary, text, diff=<driver>) and routes generated/vendored files
+	// into their own cluster instead of mixing them with hand-written code.
+	RespectGitAttributes bool `yaml:"respect_gitattributes"`
+	// AttributeWeights tunes how strongly a given attribute (e.g.
+	// "linguist-generated") pulls a change into its own cluster. Higher
+	// values make the change less likely to merge with anything else.
+	AttributeWeights map[string]float64 `yaml:"attribute_weights"`
+	// UseBlame runs `git blame` against each changed file's pre-image so
+	// cluster descriptions can surface who last touched the removed lines
+	// (e.g. "reverts 3 lines from \"add retry loop\""). Off by default:
+	// blame on large files is expensive.
+	UseBlame bool `yaml:"use_blame"`
 }
 
 type TestGeneration struct {
@@ -35,6 +75,28 @@ type TestGeneration struct {
 	Frameworks []string `yaml:"frameworks"`
 	OutputDir  string   `yaml:"output_dir"`
 	AutoStage  bool     `yaml:"auto_stage"`
+	Fuzz       Fuzz     `yaml:"fuzz"`
+}
+
+// Fuzz controls emission of Go 1.18+ native fuzz targets (FuzzX(f
+// *testing.F)) alongside the table-driven tests TestGeneration already
+// produces, for any changed function whose parameters are all fuzzable.
+type Fuzz struct {
+	Enabled bool `yaml:"enabled"`
+	// MinParams/MaxParams bound how many parameters a function may have to
+	// be considered for fuzzing -- a 0-arg function has nothing to fuzz,
+	// and a function with many parameters is usually a sign fuzzing one
+	// input at a time won't exercise it meaningfully.
+	MinParams int `yaml:"min_params"`
+	MaxParams int `yaml:"max_params"`
+	// ExcludeTypes skips a function if any parameter's type string matches
+	// one listed here (e.g. "context.Context" on a function that otherwise
+	// looks fuzzable).
+	ExcludeTypes []string `yaml:"exclude_types"`
+	// CorpusDir is where seed corpus files are written, as
+	// <corpus_dir>/FuzzX/<hash>; defaults to "testdata/fuzz" to match
+	// `go test -fuzz`'s own default corpus location.
+	CorpusDir string `yaml:"corpus_dir"`
 }
 
 type Security struct {
@@ -42,6 +104,127 @@ type Security struct {
 	Analyzers    []string `yaml:"analyzers"`
 	BlockOnHigh  bool     `yaml:"block_on_high"`
 	IncludeInMsg bool     `yaml:"include_in_msg"`
+	// DemoteUnreachable downgrades a dependency-scanner finding (safety,
+	// cargo-audit) to "informational" severity when none of the diff's
+	// changed files appear to import the vulnerable package, so an
+	// unreachable advisory no longer trips BlockOnHigh.
+	DemoteUnreachable bool `yaml:"demote_unreachable"`
+	// Plugins are external analyzers declared here instead of compiled into
+	// gitmind (Trivy, KICS, Checkov, an in-house regex pack, ...). A plugin
+	// name may also be listed in Analyzers like any built-in.
+	Plugins []AnalyzerPlugin `yaml:"plugins"`
+	// Timeouts overrides GlobalTimeout for an individual analyzer, keyed by
+	// name (e.g. {"semgrep": "45s"}), parsed with time.ParseDuration.
+	Timeouts map[string]string `yaml:"timeouts"`
+	// GlobalTimeout bounds the total wall-clock time AnalyzeDiff spends
+	// running analyzers, no matter how many are configured. Empty means a
+	// 2-minute default.
+	GlobalTimeout string `yaml:"global_timeout"`
+	// Verbose reports each analyzer's pending/running/done/failed/timed-out
+	// state as it runs, instead of staying silent until AnalyzeDiff returns.
+	// Set by the --security-verbose CLI flag; rarely worth persisting.
+	Verbose bool `yaml:"verbose"`
+	// BaselinePath is where LoadBaseline/SaveBaseline read and write the
+	// snapshot of pre-existing whole-file-scanner findings a repo has
+	// accepted. Empty means ".gitmind-security-baseline.json".
+	BaselinePath string `yaml:"baseline_path"`
+	// Suppress lists explicit suppression rules, independent of the
+	// baseline file, each with a Reason for audit trails and an optional
+	// Expiry so an exception doesn't silently apply forever.
+	Suppress []SuppressionRule `yaml:"suppress"`
+	// SecretEntropyThreshold is the Shannon entropy (bits per character)
+	// above which a string that doesn't match any secrets rule is still
+	// flagged as "secret-generic". Zero means secrets.DefaultEntropyThreshold.
+	SecretEntropyThreshold float64 `yaml:"secret_entropy_threshold"`
+	// MaxPatternFileSize bounds, in bytes, how large a file
+	// analyzeFileWithPatterns will read. Zero means
+	// security.defaultMaxPatternFileSize.
+	MaxPatternFileSize int64 `yaml:"max_pattern_file_size"`
+	// SecretFileAllowlist lists SHA-256 checksums (hex) of whole file
+	// contents that analyzeFileWithPatterns's secret-detection pass should
+	// skip entirely, for a file that's a known fixture/test vector rather
+	// than a real leaked credential.
+	SecretFileAllowlist []string `yaml:"secret_file_allowlist"`
+	// IgnorePath is where LoadIgnoreFile reads a .gitmindignore-shaped
+	// vulnerability-status suppression list. Empty means
+	// security.DefaultIgnorePath. Unlike Suppress, an ignore entry doesn't
+	// drop its finding -- it sets Status and moves it into the report's
+	// Ignored list, so the lifecycle decision stays visible.
+	IgnorePath string `yaml:"ignore_path"`
+	// Ignore declares the same per-CVE/per-rule status suppressions
+	// LoadIgnoreFile parses from a .gitmindignore file, but inline in
+	// gitmind.yaml for teams that would rather keep one config file than
+	// two. Entries from both sources are combined.
+	Ignore []IgnoreRule `yaml:"ignore"`
+	// SarifPath is where cmdGenerate writes the combined SARIF 2.1.0 log
+	// for every commit's findings, so a CI job can pick it up without
+	// running `gitmind security export-sarif` itself. Empty means
+	// security.DefaultSarifPath. Set to "-" to disable the automatic write.
+	SarifPath string `yaml:"sarif_path"`
+	// ForbiddenImports declares import-path patterns the "forbidden-imports"
+	// analyzer should flag in changed .go files, e.g. banning stdlib
+	// "errors" in favor of "github.com/pkg/errors".
+	ForbiddenImports []ForbiddenImportRule `yaml:"forbidden_imports"`
+}
+
+// ForbiddenImportRule flags any import matching Pattern (a path.Match glob
+// against the import path, e.g. "errors", "golang.org/x/net/context",
+// "github.com/pkg/errors/*") in a changed .go file. Paths optionally scopes
+// the rule to certain files: a glob with no "/" matches against the
+// file's base name, one with a "/" against its full path, and a "!"-
+// prefixed glob excludes matching files instead of requiring them --
+// e.g. Paths: []string{"!*_test.go"} to deny an import everywhere except
+// test files.
+type ForbiddenImportRule struct {
+	Pattern string   `yaml:"pattern"`
+	Reason  string   `yaml:"reason"`
+	Paths   []string `yaml:"paths"`
+}
+
+// IgnoreRule records that a finding matching ID (a Finding.Rule, or a
+// CVE/advisory ID embedded anywhere in Finding.Type) should be recorded
+// with the given lifecycle Status instead of counted as an open finding.
+// Reason documents why, and Expiry, if set, is a "2006-01-02" date after
+// which the rule stops applying.
+type IgnoreRule struct {
+	ID     string `yaml:"id"`
+	Status string `yaml:"status"`
+	Reason string `yaml:"reason"`
+	Expiry string `yaml:"expiry"`
+}
+
+// SuppressionRule mutes findings matching File and/or Rule (either may be
+// left empty to match any value). Expiry, if set, is a "2006-01-02" date
+// after which the rule stops applying.
+type SuppressionRule struct {
+	File   string `yaml:"file"`
+	Rule   string `yaml:"rule"`
+	Reason string `yaml:"reason"`
+	Expiry string `yaml:"expiry"`
+}
+
+// AnalyzerPlugin describes an external security scanner that
+// SecurityAnalyzer runs via os/exec instead of a compiled-in analyzer. The
+// plugin receives the changed files as a JSON array of strings on stdin,
+// and is expected to write its findings to stdout encoded as Format.
+type AnalyzerPlugin struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+	// Format selects which parser decodes the plugin's stdout: "sarif",
+	// "semgrep" (Semgrep's native --json report), or "json" (gitmind's own
+	// []security.Finding shape). Defaults to "json" if empty.
+	Format string `yaml:"format"`
+}
+
+// RepoConfig selects which internal/repo.Reader backend answers read-only
+// git queries (staged diff, range diff, blame, repo root).
+type RepoConfig struct {
+	// Backend names the registered internal/repo reader to use. Empty
+	// means "exec", the git-CLI-backed implementation; "go-git" is
+	// reserved for once github.com/go-git/go-git/v5 is vendored (see the
+	// commented require in go.mod).
+	Backend string `yaml:"backend"`
 }
 
 type Config struct {
@@ -52,6 +235,16 @@ type Config struct {
 	MultiCommit     MultiCommit    `yaml:"multi_commit"`
 	TestGeneration  TestGeneration `yaml:"test_generation"`
 	Security        Security       `yaml:"security"`
+	Repo            RepoConfig     `yaml:"repo"`
+	// Profiles holds named configuration overlays selectable via
+	// LoadProfile/--profile or the GITMIND_PROFILE environment variable,
+	// e.g. a "fast" profile that disables the LLM model in favor of the
+	// heuristic message generator. Each entry is kept as a raw yaml.Node
+	// rather than unmarshaled into a Config up front, so applying it only
+	// overrides the keys it actually sets -- the same sparse-merge
+	// property a config file has over the layer below it -- instead of
+	// zeroing out every field the profile didn't mention.
+	Profiles map[string]yaml.Node `yaml:"profiles,omitempty"`
 }
 
 func defaultConfig() Config {
@@ -59,31 +252,119 @@ func defaultConfig() Config {
 		Style:           "conventional",
 		MaxSummaryLines: 15,
 		Model:           Model{Enabled: false, Provider: "llama.cpp", NCtx: 4096, NThreads: 4, Temp: 0.2, TopP: 0.9, MaxTokens: 256},
-		Prompt:          Prompt{Preface: "You are an assistant that writes precise Git commit messages.", Rules: "- Prefer imperative mood\n- Keep subject ≤ 72 chars"},
-		MultiCommit:     MultiCommit{Enabled: false, MaxClusters: 3, SimilarityThreshold: 0.7, PromptUser: true},
-		TestGeneration:  TestGeneration{Enabled: false, Frameworks: []string{"testing"}, OutputDir: ".", AutoStage: false},
-		Security:        Security{Enabled: false, Analyzers: []string{"gosec"}, BlockOnHigh: false, IncludeInMsg: true},
+		Prompt: Prompt{
+			Preface: "You are an assistant that writes precise Git commit messages.",
+			Rules:   "- Prefer imperative mood\n- Keep subject ≤ 72 chars",
+			FewShot: FewShot{Enabled: false, Count: 3, MaxDiffBytes: 2000, MinMessageLen: 10},
+		},
+		MultiCommit: MultiCommit{
+			Enabled:              false,
+			MaxClusters:          3,
+			SimilarityThreshold:  0.7,
+			PromptUser:           true,
+			RespectGitAttributes: false,
+			AttributeWeights: map[string]float64{
+				"linguist-generated": 1.0,
+				"linguist-vendored":  1.0,
+			},
+			UseBlame: false,
+		},
+		TestGeneration: TestGeneration{
+			Enabled:    false,
+			Frameworks: []string{"testing"},
+			OutputDir:  ".",
+			AutoStage:  false,
+			Fuzz: Fuzz{
+				Enabled:   false,
+				MinParams: 1,
+				MaxParams: 4,
+				CorpusDir: "testdata/fuzz",
+			},
+		},
+		Security: Security{
+			Enabled:           false,
+			Analyzers:         []string{"gosec", "config-audit"},
+			BlockOnHigh:       false,
+			IncludeInMsg:      true,
+			DemoteUnreachable: false,
+			GlobalTimeout:     "2m",
+		},
+		Repo: RepoConfig{
+			Backend: "exec",
+		},
+	}
+}
+
+// configPaths lists every config file Load consults, lowest precedence
+// first: $XDG_CONFIG_HOME/gitmind/config.yaml (falling back to
+// ~/.config/gitmind/config.yaml per the XDG base-dir spec's default),
+// ~/.gitmind.yaml, then ./.gitmind.yaml. Each is merged onto the previous
+// layer with loadYAML, so a later file only needs to mention the keys it
+// wants to change.
+func configPaths() []string {
+	var paths []string
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "gitmind", "config.yaml"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "gitmind", "config.yaml"))
 	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".gitmind.yaml"))
+	}
+
+	paths = append(paths, ".gitmind.yaml")
+	return paths
 }
 
+// Load resolves the full precedence chain -- built-in defaults, each of
+// configPaths in increasing priority, the selected profile (GITMIND_PROFILE),
+// then GITMIND_* environment variable overrides -- and validates the
+// result, logging (not failing) on an invalid value so a typo in one
+// setting doesn't take down every gitmind invocation.
 func Load() Config {
+	return LoadProfile(os.Getenv("GITMIND_PROFILE"))
+}
+
+// LoadProfile is Load, additionally applying the named profile (if
+// non-empty) on top of the merged config files, for callers that take an
+// explicit --profile flag rather than relying on GITMIND_PROFILE.
+func LoadProfile(profile string) Config {
 	cfg := defaultConfig()
-	// repo-level overrides
-	if loadYAML(".gitmind.yaml", &cfg) == nil {
-		return cfg
+	for _, path := range configPaths() {
+		_ = loadYAML(path, &cfg)
 	}
-	// try the old name for backwards compatibility
-	if loadYAML(".gitmind.yaml", &cfg) == nil {
-		return cfg
+
+	if profile != "" {
+		if err := applyProfile(&cfg, profile); err != nil {
+			fmt.Fprintf(os.Stderr, "gitmind: %v\n", err)
+		}
 	}
-	// home-level
-	if home, err := os.UserHomeDir(); err == nil {
-		_ = loadYAML(filepath.Join(home, ".gitmind.yaml"), &cfg)
-		_ = loadYAML(filepath.Join(home, ".gitmind.yaml"), &cfg)
+
+	applyEnvOverrides(&cfg)
+
+	if err := Validate(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "gitmind: invalid configuration: %v\n", err)
 	}
+
 	return cfg
 }
 
+// applyProfile merges profile's raw YAML node onto cfg, the same way a
+// config file layers onto the defaults below it.
+func applyProfile(cfg *Config, profile string) error {
+	node, ok := cfg.Profiles[profile]
+	if !ok {
+		return fmt.Errorf("unknown profile %q", profile)
+	}
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("profile %q: %v", profile, err)
+	}
+	return yaml.Unmarshal(data, cfg)
+}
+
 func loadYAML(path string, out any) error {
 	b, err := os.ReadFile(path)
 	if err != nil {
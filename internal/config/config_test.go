@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withEnv sets key to value for the duration of the test, restoring (or
+// unsetting) the prior value on cleanup.
+func withEnv(t *testing.T, key, value string) {
+	t.Helper()
+	prev, had := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if had {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	prev, had := os.LookupEnv(key)
+	os.Unsetenv(key)
+	t.Cleanup(func() {
+		if had {
+			os.Setenv(key, prev)
+		}
+	})
+}
+
+func TestLoadRepoConfigOutranksHomeConfig(t *testing.T) {
+	// Regression test for the precedence bug configPaths' doc comment
+	// calls out: a repo-local .gitmind.yaml must win over ~/.gitmind.yaml,
+	// not the other way around.
+	home := t.TempDir()
+	repo := t.TempDir()
+
+	withEnv(t, "HOME", home)
+	unsetEnv(t, "XDG_CONFIG_HOME")
+	unsetEnv(t, "GITMIND_PROFILE")
+
+	if err := os.WriteFile(filepath.Join(home, ".gitmind.yaml"), []byte("style: home-style\n"), 0o644); err != nil {
+		t.Fatalf("write home config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitmind.yaml"), []byte("style: repo-style\n"), 0o644); err != nil {
+		t.Fatalf("write repo config: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	cfg := Load()
+	if cfg.Style != "repo-style" {
+		t.Fatalf("cfg.Style = %q, want %q (repo config must outrank home config)", cfg.Style, "repo-style")
+	}
+}
+
+func TestLoadYAMLMergeIsSparse(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Security.BlockOnHigh = true
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("style: custom\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if err := loadYAML(path, &cfg); err != nil {
+		t.Fatalf("loadYAML: %v", err)
+	}
+	if cfg.Style != "custom" {
+		t.Fatalf("cfg.Style = %q, want custom", cfg.Style)
+	}
+	if !cfg.Security.BlockOnHigh {
+		t.Fatalf("loadYAML must not clobber fields the file doesn't mention")
+	}
+}
+
+func TestApplyEnvOverridesSetsNestedField(t *testing.T) {
+	withEnv(t, "GITMIND_SECURITY_BLOCK_ON_HIGH", "true")
+	withEnv(t, "GITMIND_MAX_SUMMARY_LINES", "42")
+
+	cfg := defaultConfig()
+	applyEnvOverrides(&cfg)
+
+	if !cfg.Security.BlockOnHigh {
+		t.Fatalf("GITMIND_SECURITY_BLOCK_ON_HIGH=true did not set Security.BlockOnHigh")
+	}
+	if cfg.MaxSummaryLines != 42 {
+		t.Fatalf("MaxSummaryLines = %d, want 42", cfg.MaxSummaryLines)
+	}
+}
+
+func TestApplyEnvOverridesIgnoresUnrelatedVars(t *testing.T) {
+	withEnv(t, "GITMIND_UNKNOWN_FIELD", "whatever")
+
+	before := defaultConfig()
+	cfg := defaultConfig()
+	applyEnvOverrides(&cfg)
+
+	if !reflect.DeepEqual(cfg, before) {
+		t.Fatalf("an env var with no matching field must not alter cfg: got %+v, want %+v", cfg, before)
+	}
+}
+
+func TestApplyProfileOverlaysOnlyItsOwnKeys(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Security.BlockOnHigh = true
+
+	var profileNode yaml.Node
+	if err := yaml.Unmarshal([]byte("style: fast\n"), &profileNode); err != nil {
+		t.Fatalf("unmarshal profile node: %v", err)
+	}
+	// A mapping document unmarshals into a DocumentNode wrapping a
+	// MappingNode; applyProfile re-marshals whatever's stored in
+	// cfg.Profiles, so store the content node the same way Load's own
+	// YAML unmarshaling into map[string]yaml.Node would.
+	cfg.Profiles = map[string]yaml.Node{"fast": *profileNode.Content[0]}
+
+	if err := applyProfile(&cfg, "fast"); err != nil {
+		t.Fatalf("applyProfile: %v", err)
+	}
+	if cfg.Style != "fast" {
+		t.Fatalf("cfg.Style = %q, want fast", cfg.Style)
+	}
+	if !cfg.Security.BlockOnHigh {
+		t.Fatalf("applyProfile must not clobber fields the profile doesn't mention")
+	}
+}
+
+func TestApplyProfileUnknownNameErrors(t *testing.T) {
+	cfg := defaultConfig()
+	if err := applyProfile(&cfg, "nonexistent"); err == nil {
+		t.Fatalf("expected an error for an unknown profile")
+	}
+}
+
+func TestValidateFlagsUnknownAnalyzer(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Security.Analyzers = []string{"gosec", "not-a-real-analyzer"}
+	if err := Validate(cfg); err == nil {
+		t.Fatalf("expected an error for an unknown analyzer")
+	}
+}
+
+func TestValidateAllowsPluginNamedAnalyzer(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Security.Plugins = []AnalyzerPlugin{{Name: "trivy", Command: "trivy"}}
+	cfg.Security.Analyzers = []string{"gosec", "trivy"}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateFlagsInvalidGlobalTimeout(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Security.GlobalTimeout = "not-a-duration"
+	if err := Validate(cfg); err == nil {
+		t.Fatalf("expected an error for an invalid global_timeout")
+	}
+}
+
+func TestValidateFlagsSimilarityThresholdOutOfRange(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.MultiCommit.Enabled = true
+	cfg.MultiCommit.SimilarityThreshold = 1.5
+	if err := Validate(cfg); err == nil {
+		t.Fatalf("expected an error for a similarity_threshold outside [0, 1]")
+	}
+}
+
+func TestValidateDefaultConfigIsValid(t *testing.T) {
+	if err := Validate(defaultConfig()); err != nil {
+		t.Fatalf("Validate(defaultConfig()): %v", err)
+	}
+}
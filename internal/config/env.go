@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// envPrefix is the namespace every environment-variable override lives
+// under, e.g. GITMIND_MODEL_ENABLED, GITMIND_SECURITY_BLOCK_ON_HIGH.
+const envPrefix = "GITMIND"
+
+// applyEnvOverrides walks cfg's fields by their yaml tag, checking for a
+// GITMIND_<SECTION>_<FIELD> environment variable at each leaf (so
+// Security.BlockOnHigh's "block_on_high" tag becomes
+// GITMIND_SECURITY_BLOCK_ON_HIGH) and setting it when present. This is the
+// last layer in Load's precedence chain, above every config file and
+// profile.
+func applyEnvOverrides(cfg *Config) {
+	env := environMap()
+	if len(env) == 0 {
+		return
+	}
+	setFromEnv(reflect.ValueOf(cfg).Elem(), envPrefix, env)
+}
+
+func environMap() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, envPrefix+"_") {
+			continue
+		}
+		if idx := strings.IndexByte(kv, '='); idx >= 0 {
+			env[kv[:idx]] = kv[idx+1:]
+		}
+	}
+	return env
+}
+
+func setFromEnv(v reflect.Value, prefix string, env map[string]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		tag := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		key := prefix + "_" + strings.ToUpper(tag)
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Struct {
+			setFromEnv(fv, key, env)
+			continue
+		}
+
+		if raw, ok := env[key]; ok {
+			setEnvValue(fv, raw)
+		}
+	}
+}
+
+func setEnvValue(fv reflect.Value, raw string) {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			fv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			fv.SetInt(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			fv.SetFloat(f)
+		}
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		parts := strings.Split(raw, ",")
+		out := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			out.Index(i).SetString(strings.TrimSpace(p))
+		}
+		fv.Set(out)
+	}
+}
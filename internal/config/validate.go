@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// knownAnalyzers mirrors the builtin names internal/security/analyzer.go
+// registers via registerBuiltin. Kept here rather than imported because
+// internal/security already imports this package, and as the name an
+// analyzer is registered under, not a behavioral choice -- so duplicating
+// the literal list is a smaller liability than an import cycle.
+var knownAnalyzers = map[string]bool{
+	"gosec": true, "bandit": true, "eslint-security": true, "semgrep": true,
+	"safety": true, "brakeman": true, "spotbugs": true, "psalm": true,
+	"phpstan": true, "cppcheck": true, "flawfinder": true, "cargo-audit": true,
+	"clippy": true, "securecodewarrior": true, "config-audit": true,
+	"staticcheck": true, "govet": true, "golangci-lint": true,
+	"forbidden-imports": true,
+}
+
+// Validate checks cfg against the constraints schema.json (checked in
+// alongside this package) documents structurally -- a JSON Schema
+// validator isn't vendored, so this mirrors the same rules by hand for the
+// settings most likely to contain a typo, rather than attempting full
+// schema coverage. Errors are joined so a caller sees every problem at
+// once instead of stopping at the first.
+func Validate(cfg Config) error {
+	var errs []error
+
+	for i, name := range cfg.Security.Analyzers {
+		if knownAnalyzers[name] {
+			continue
+		}
+		if hasPlugin(cfg.Security.Plugins, name) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("security.analyzers[%d]: unknown analyzer %q", i, name))
+	}
+
+	if cfg.Security.GlobalTimeout != "" {
+		if _, err := time.ParseDuration(cfg.Security.GlobalTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("security.global_timeout: %v", err))
+		}
+	}
+	for name, d := range cfg.Security.Timeouts {
+		if _, err := time.ParseDuration(d); err != nil {
+			errs = append(errs, fmt.Errorf("security.timeouts[%q]: %v", name, err))
+		}
+	}
+
+	if t := cfg.MultiCommit.SimilarityThreshold; cfg.MultiCommit.Enabled && (t < 0 || t > 1) {
+		errs = append(errs, fmt.Errorf("multi_commit.similarity_threshold: %v is outside [0, 1]", t))
+	}
+	if cfg.MultiCommit.Enabled && cfg.MultiCommit.MaxClusters < 1 {
+		errs = append(errs, fmt.Errorf("multi_commit.max_clusters: must be at least 1, got %d", cfg.MultiCommit.MaxClusters))
+	}
+
+	if f := cfg.TestGeneration.Fuzz; f.Enabled && f.MinParams > f.MaxParams {
+		errs = append(errs, fmt.Errorf("test_generation.fuzz: min_params (%d) is greater than max_params (%d)", f.MinParams, f.MaxParams))
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasPlugin(plugins []AnalyzerPlugin, name string) bool {
+	for _, p := range plugins {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"context"
+	"os"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch reloads config via Load whenever any of configPaths changes on
+// disk, and calls onChange with the result -- so a long-running invocation
+// (a future daemon mode, an editor-integrated watcher) picks up edits
+// without restarting. It blocks until ctx is canceled, at which point it
+// closes the underlying watcher and returns ctx.Err().
+//
+// A file that doesn't exist yet (most repos have no ~/.gitmind.yaml) is
+// skipped rather than erroring: fsnotify can only watch paths that already
+// exist, and a config file created later is picked up the next time Watch
+// is called, matching Load's own "missing file = use defaults" ergonomics.
+func Watch(ctx context.Context, onChange func(Config)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, path := range configPaths() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		_ = watcher.Add(path)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				onChange(Load())
+			}
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
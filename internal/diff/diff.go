@@ -1,23 +1,40 @@
 package diff
 
 import (
-	"bytes"
-	"os/exec"
+	"context"
 	"regexp"
+	"strconv"
 	"strings"
 
-	"github.com/yourname/commitgen/internal/config"
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/gitexec"
 )
 
 func Staged() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached", "-U0")
-	var buf bytes.Buffer
-	cmd.Stdout = &buf
-	cmd.Stderr = &buf
-	if err := cmd.Run(); err != nil {
-		return "", err
-	}
-	return buf.String(), nil
+	return gitexec.New("diff").AddFlags("--cached", "-U0").Run(context.Background())
+}
+
+// LastCommit returns the diff HEAD introduced against its parent, for
+// callers that run after the commit already happened (a post-commit hook)
+// and so have nothing staged to read anymore.
+func LastCommit() (string, error) {
+	return gitexec.New("diff").AddFlags("-U0").AddDynamic("HEAD~1", "HEAD").Run(context.Background())
+}
+
+// Range returns the diff across rangeSpec (e.g. "v1.2.0..HEAD" or
+// "abc123^..abc123"), for a caller generating a message or changelog
+// against something other than the staged changes -- `gitmind generate
+// --range`/`--commit` and `gitmind summarize`.
+func Range(rangeSpec string) (string, error) {
+	return gitexec.New("diff").AddFlags("-U0").AddDynamic(rangeSpec).Run(context.Background())
+}
+
+// Log returns `git log`'s output over rangeSpec formatted with format
+// (e.g. "%H %s" for hash-and-subject lines), oldest first -- the
+// changelog-style context `gitmind summarize` and `gitmind generate
+// --range` pair with the range's diff.
+func Log(rangeSpec, format string) (string, error) {
+	return gitexec.New("log").AddFlags("--reverse", "--format="+format).AddDynamic(rangeSpec).Run(context.Background())
 }
 
 func HeuristicMessage(d string, cfg config.Config) string {
@@ -73,7 +90,7 @@ func summarizeFiles(files []string) string {
 	if len(files) == 2 {
 		return "update " + files[0] + ", " + files[1]
 	}
-	return "update " + files[0] + " and " + string(len(files)-1+'0') + " more files"
+	return "update " + files[0] + " and " + strconv.Itoa(len(files)-1) + " more files"
 }
 
 func summarizeHunks(d string, maxLines int) string {
@@ -123,3 +140,199 @@ func unique(in []string) []string {
 	}
 	return out
 }
+
+// LineKind is what a DiffLine represents in a unified diff hunk.
+type LineKind int
+
+const (
+	LineContext LineKind = iota
+	LineAdded
+	LineRemoved
+)
+
+// DiffLine is one line of a Hunk. OldLineNo is 0 for an added line (it has
+// no pre-image position); NewLineNo is 0 for a removed line.
+type DiffLine struct {
+	Kind      LineKind
+	OldLineNo int
+	NewLineNo int
+	Text      string
+}
+
+// Hunk is one `@@ -OldStart,OldLines +NewStart,NewLines @@` block, with
+// every line's true pre-/post-image line number already resolved.
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []DiffLine
+}
+
+// FileDiff is every hunk touching one file. IsRename is set from an
+// explicit `rename from`/`rename to` pair; IsBinary files carry no hunks.
+type FileDiff struct {
+	OldPath  string
+	NewPath  string
+	IsRename bool
+	IsBinary bool
+	Hunks    []Hunk
+}
+
+// AddedLines returns every line this file gained, across all hunks, in
+// post-image order.
+func (f FileDiff) AddedLines() []DiffLine {
+	var out []DiffLine
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			if l.Kind == LineAdded {
+				out = append(out, l)
+			}
+		}
+	}
+	return out
+}
+
+// Path returns the file's post-image path, falling back to its pre-image
+// path for a deletion (NewPath is empty when the diff's "+++" side is
+// /dev/null).
+func (f FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Parse parses a unified diff into one FileDiff per file, in the order
+// they appear in raw. Unlike the ad-hoc "@@ header, then increment on
+// every + line" counting scattered through security/splitter, Parse walks
+// context lines too, so OldLineNo/NewLineNo are exact even across hunks
+// with interspersed context. Binary patches are recorded with IsBinary set
+// and no hunks; "\ No newline at end of file" markers are consumed since
+// they don't affect line numbering.
+func Parse(raw string) ([]FileDiff, error) {
+	var files []FileDiff
+	var cur *FileDiff
+	var hunk *Hunk
+	var oldLine, newLine int
+
+	flushHunk := func() {
+		if hunk != nil && cur != nil {
+			cur.Hunks = append(cur.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if cur != nil {
+			files = append(files, *cur)
+		}
+		cur = nil
+	}
+
+	for _, line := range strings.Split(raw, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushFile()
+			cur = &FileDiff{}
+			if parts := strings.Fields(line); len(parts) >= 4 {
+				cur.OldPath = strings.TrimPrefix(parts[2], "a/")
+				cur.NewPath = strings.TrimPrefix(parts[3], "b/")
+			}
+
+		case strings.HasPrefix(line, "rename from "):
+			if cur != nil {
+				cur.OldPath = strings.TrimPrefix(line, "rename from ")
+				cur.IsRename = true
+			}
+
+		case strings.HasPrefix(line, "rename to "):
+			if cur != nil {
+				cur.NewPath = strings.TrimPrefix(line, "rename to ")
+				cur.IsRename = true
+			}
+
+		case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"):
+			if cur != nil {
+				cur.IsBinary = true
+			}
+
+		case strings.HasPrefix(line, "--- "):
+			if cur != nil {
+				cur.OldPath = trimDiffPathPrefix(strings.TrimPrefix(line, "--- "))
+			}
+
+		case strings.HasPrefix(line, "+++ "):
+			if cur != nil {
+				cur.NewPath = trimDiffPathPrefix(strings.TrimPrefix(line, "+++ "))
+			}
+
+		case strings.HasPrefix(line, "@@"):
+			flushHunk()
+			if cur == nil {
+				continue
+			}
+			m := hunkHeaderRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			oldStart, _ := strconv.Atoi(m[1])
+			oldCount := 1
+			if m[2] != "" {
+				oldCount, _ = strconv.Atoi(m[2])
+			}
+			newStart, _ := strconv.Atoi(m[3])
+			newCount := 1
+			if m[4] != "" {
+				newCount, _ = strconv.Atoi(m[4])
+			}
+			hunk = &Hunk{OldStart: oldStart, OldLines: oldCount, NewStart: newStart, NewLines: newCount}
+			oldLine, newLine = oldStart, newStart
+
+		case strings.HasPrefix(line, `\ No newline at end of file`):
+			continue
+
+		case hunk != nil && strings.HasPrefix(line, "+"):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineAdded, NewLineNo: newLine, Text: line[1:]})
+			newLine++
+
+		case hunk != nil && strings.HasPrefix(line, "-"):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineRemoved, OldLineNo: oldLine, Text: line[1:]})
+			oldLine++
+
+		case hunk != nil && strings.HasPrefix(line, " "):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineContext, OldLineNo: oldLine, NewLineNo: newLine, Text: line[1:]})
+			oldLine++
+			newLine++
+		}
+	}
+	flushFile()
+
+	return files, nil
+}
+
+// ChangedFiles returns each non-binary FileDiff's Path(), in diff order.
+func ChangedFiles(files []FileDiff) []string {
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsBinary {
+			continue
+		}
+		if p := f.Path(); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func trimDiffPathPrefix(p string) string {
+	if p == "/dev/null" {
+		return ""
+	}
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
@@ -0,0 +1,121 @@
+package diff
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	f.Add("")
+	f.Add(`diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++
+ func main() {
+ }
+`)
+	f.Add(`diff --git a/old.go b/new.go
+similarity index 100%
+rename from old.go
+rename to new.go
+`)
+	f.Add(`diff --git a/image.png b/image.png
+index 3333333..4444444 100644
+Binary files a/image.png and b/image.png differ
+`)
+	f.Add(`diff --git a/file.txt b/file.txt
+index 5555555..6666666 100644
+--- a/file.txt
++++ b/file.txt
+@@ -1 +1 @@
+-old line
+\ No newline at end of file
++new line
+\ No newline at end of file
+`)
+	f.Add(`diff --git a/deleted.go b/deleted.go
+deleted file mode 100644
+index 7777777..0000000
+--- a/deleted.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package deleted
+-
+`)
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		files, err := Parse(raw)
+		if err != nil {
+			t.Fatalf("Parse returned an error for input it should only ever parse leniently: %v", err)
+		}
+		for _, fd := range files {
+			for _, h := range fd.Hunks {
+				for _, l := range h.Lines {
+					if l.OldLineNo < 0 || l.NewLineNo < 0 {
+						t.Fatalf("negative line number in %q: %+v", fd.Path(), l)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestParseHunkLineNumbers(t *testing.T) {
+	raw := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++
+ func main() {
+ }
+`
+	files, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+
+	added := files[0].AddedLines()
+	if len(added) != 1 {
+		t.Fatalf("got %d added lines, want 1", len(added))
+	}
+	if added[0].NewLineNo != 2 {
+		t.Fatalf("added line at post-image line %d, want 2", added[0].NewLineNo)
+	}
+}
+
+func TestParseRename(t *testing.T) {
+	raw := `diff --git a/old.go b/new.go
+similarity index 100%
+rename from old.go
+rename to new.go
+`
+	files, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(files) != 1 || !files[0].IsRename {
+		t.Fatalf("expected a single renamed FileDiff, got %+v", files)
+	}
+	if files[0].Path() != "new.go" {
+		t.Fatalf("Path() = %q, want new.go", files[0].Path())
+	}
+}
+
+func TestParseBinarySkipped(t *testing.T) {
+	raw := `diff --git a/image.png b/image.png
+index 3333333..4444444 100644
+Binary files a/image.png and b/image.png differ
+`
+	files, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(ChangedFiles(files)) != 0 {
+		t.Fatalf("ChangedFiles should skip binary patches, got %v", ChangedFiles(files))
+	}
+}
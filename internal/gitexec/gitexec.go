@@ -0,0 +1,133 @@
+// Package gitexec centralizes every git invocation behind one builder, so
+// a caller threading a branch name, file path, or ref from a config file
+// or LLM output into a git command can't accidentally have it parsed as a
+// flag. AddDynamic validates that a revision-like argument doesn't start
+// with "-"; AddPaths instead puts pathspec arguments after a literal "--"
+// separator, git's own way of saying "everything after this is a path,
+// not an option." It also applies a per-command timeout via
+// context.Context, captures stdout and stderr into separate buffers
+// instead of merging them, and wraps a failing command's exit code and
+// stderr text into *Error.
+package gitexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds how long Run waits for git before killing it, for
+// a caller that doesn't set its own via WithTimeout.
+const DefaultTimeout = 30 * time.Second
+
+// Command builds one git invocation: a subcommand, trusted flags, dynamic
+// revision-like arguments, and dynamic pathspec arguments, each kept
+// apart from the others.
+type Command struct {
+	name    string
+	flags   []string
+	dynamic []string
+	paths   []string
+	timeout time.Duration
+}
+
+// New starts a Command for git's subcommand name, e.g. "diff" or "blame".
+func New(name string) *Command {
+	return &Command{name: name, timeout: DefaultTimeout}
+}
+
+// AddFlags appends trusted, literal arguments -- switches and options the
+// caller hard-coded, never derived from config or user input.
+func (c *Command) AddFlags(flags ...string) *Command {
+	c.flags = append(c.flags, flags...)
+	return c
+}
+
+// AddDynamic appends arguments whose content isn't trusted but must still
+// be parsed positionally -- a ref, a branch name, a commit hash -- and so
+// can't simply be moved after a pathspec "--" without changing what git
+// thinks they are. Run rejects one starting with "-" instead of letting
+// it be mistaken for a flag.
+func (c *Command) AddDynamic(args ...string) *Command {
+	c.dynamic = append(c.dynamic, args...)
+	return c
+}
+
+// AddPaths appends pathspec arguments -- file or directory paths -- after
+// a literal "--" separator, so one starting with "-" is unambiguously a
+// path to git rather than an option.
+func (c *Command) AddPaths(paths ...string) *Command {
+	c.paths = append(c.paths, paths...)
+	return c
+}
+
+// WithTimeout overrides DefaultTimeout for this Command.
+func (c *Command) WithTimeout(d time.Duration) *Command {
+	c.timeout = d
+	return c
+}
+
+// Run executes the built command and returns stdout. A non-zero exit
+// wraps the exit code and stderr text into *Error rather than returning
+// stdout and stderr merged the way some of this package's predecessors
+// did.
+func (c *Command) Run(ctx context.Context) (string, error) {
+	for _, a := range c.dynamic {
+		if strings.HasPrefix(a, "-") {
+			return "", fmt.Errorf("gitexec: dynamic argument %q looks like a flag", a)
+		}
+	}
+
+	args := make([]string, 0, len(c.flags)+len(c.dynamic)+len(c.paths)+2)
+	args = append(args, c.name)
+	args = append(args, c.flags...)
+	args = append(args, c.dynamic...)
+	if len(c.paths) > 0 {
+		args = append(args, "--")
+		args = append(args, c.paths...)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), &Error{Args: args, Stderr: strings.TrimSpace(stderr.String()), err: err}
+	}
+	return stdout.String(), nil
+}
+
+// Error is a failed git invocation: the full argument list, its stderr
+// text, and the underlying *exec.ExitError (or context error).
+type Error struct {
+	Args   []string
+	Stderr string
+	err    error
+}
+
+func (e *Error) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("git %s: %v", strings.Join(e.Args, " "), e.err)
+	}
+	return fmt.Sprintf("git %s: %v: %s", strings.Join(e.Args, " "), e.err, e.Stderr)
+}
+
+func (e *Error) Unwrap() error { return e.err }
+
+// ExitCode returns the process's exit code, or -1 if it never ran (e.g.
+// the timeout fired first).
+func (e *Error) ExitCode() int {
+	var exitErr *exec.ExitError
+	if errors.As(e.err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,223 @@
+// Package gitx wraps the git plumbing MultiCommitManager needs --
+// reading the staged diff, backing up and restoring the index around a
+// sequence of partial commits via a dedicated ref rather than stash or
+// notes, and staging whole files or a synthesized per-hunk patch.
+//
+// The concrete implementation below still shells out to the git binary
+// rather than linking github.com/go-git/go-git/v5 (see the commented
+// require in go.mod); once that's vendored, New should return a go-git
+// backed implementation instead, built against plumbing/object.Repository
+// and a billy in-memory filesystem for tests. Repo is deliberately shaped
+// so that swap won't touch any call site, and New's callers already get
+// the benefit today: MultiCommitManager depends on the interface, not on
+// os/exec, so a test can hand it an in-memory fake instead of a real repo.
+// `gitmind doctor`'s "Deferred library integrations" section reports this
+// gap too, so it isn't only discoverable by reading this comment.
+package gitx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Hash is a git object hash, hex-encoded.
+type Hash string
+
+// Backup is a reflog-safe snapshot of the index ExecuteMultiCommit took
+// before its first commit, recorded as a ref rather than left dangling:
+// refs/gitmind/backup/<nanotime> points at a synthetic commit object
+// whose tree is the index captured by `git write-tree` and whose parent
+// is the HEAD that was current at the time. Because it's a real ref, it
+// survives the process dying mid-split -- a later `gitmind multi-commit
+// --abort` (FindLatestBackup) can still find and restore it, which the
+// previous `git stash create` snapshot (reachable only through a hash
+// nobody persisted) could not.
+type Backup struct {
+	// Ref is the backup's ref name, e.g. "refs/gitmind/backup/1690000000".
+	Ref string
+}
+
+// Empty reports whether this is the zero Backup -- no backup was taken,
+// so RestoreBackup/DeleteBackup have nothing to do.
+func (b Backup) Empty() bool { return b.Ref == "" }
+
+// Repo is the git plumbing MultiCommitManager needs.
+type Repo interface {
+	// StagedDiff returns `git diff --cached -U0`.
+	StagedDiff() (string, error)
+	// Backup snapshots the current index as a backup ref, without
+	// modifying the index or working tree, so the caller is free to
+	// reset and restage.
+	Backup() (Backup, error)
+	// RestoreBackup resets HEAD and the index back to what they were
+	// when backup was taken. A zero Backup is a no-op.
+	RestoreBackup(backup Backup) error
+	// DeleteBackup removes a backup ref once it's no longer needed (the
+	// multi-commit run it guarded finished, successfully or not). A zero
+	// Backup is a no-op.
+	DeleteBackup(backup Backup) error
+	// FindLatestBackup looks for the most recently created backup ref
+	// left behind under refs/gitmind/backup/, for `gitmind multi-commit
+	// --abort` to restore after the process that created it died before
+	// it could call RestoreBackup/DeleteBackup itself.
+	FindLatestBackup() (Backup, bool, error)
+	// Reset unstages everything without touching the working tree.
+	Reset() error
+	// StageFiles stages each file whole, `git rm`-ing one that no longer
+	// exists on disk instead of failing outright.
+	StageFiles(files []string) error
+	// StageHunks applies patch -- a standalone unified diff, possibly
+	// covering several files -- directly to the index via `git apply
+	// --cached`.
+	StageHunks(patch string) error
+	// UnstageHunks reverses patch back out of the index via `git apply
+	// --cached --reverse`, the inverse of StageHunks.
+	UnstageHunks(patch string) error
+	// Commit commits whatever is currently staged and returns its hash.
+	Commit(message string) (Hash, error)
+}
+
+type repo struct{}
+
+// New returns the git-CLI-backed Repo implementation.
+func New() Repo { return repo{} }
+
+func (repo) StagedDiff() (string, error) {
+	return run("diff", "--cached", "-U0")
+}
+
+func (repo) Backup() (Backup, error) {
+	head, err := run("rev-parse", "HEAD")
+	if err != nil {
+		return Backup{}, fmt.Errorf("rev-parse HEAD: %v", err)
+	}
+	tree, err := run("write-tree")
+	if err != nil {
+		return Backup{}, fmt.Errorf("write-tree: %v", err)
+	}
+	commit, err := run("commit-tree", strings.TrimSpace(tree), "-p", strings.TrimSpace(head), "-m", "gitmind multi-commit backup")
+	if err != nil {
+		return Backup{}, fmt.Errorf("commit-tree: %v", err)
+	}
+
+	ref := fmt.Sprintf("refs/gitmind/backup/%d", time.Now().UnixNano())
+	if _, err := run("update-ref", ref, strings.TrimSpace(commit)); err != nil {
+		return Backup{}, fmt.Errorf("update-ref %s: %v", ref, err)
+	}
+	return Backup{Ref: ref}, nil
+}
+
+func (repo) RestoreBackup(backup Backup) error {
+	if backup.Empty() {
+		return nil
+	}
+	head, err := run("rev-parse", backup.Ref+"^1")
+	if err != nil {
+		return fmt.Errorf("resolve backup parent: %v", err)
+	}
+	tree, err := run("rev-parse", backup.Ref+"^{tree}")
+	if err != nil {
+		return fmt.Errorf("resolve backup tree: %v", err)
+	}
+	if _, err := run("update-ref", "HEAD", strings.TrimSpace(head)); err != nil {
+		return fmt.Errorf("update-ref HEAD: %v", err)
+	}
+	if _, err := run("read-tree", strings.TrimSpace(tree)); err != nil {
+		return fmt.Errorf("read-tree: %v", err)
+	}
+	return nil
+}
+
+func (repo) DeleteBackup(backup Backup) error {
+	if backup.Empty() {
+		return nil
+	}
+	_, err := run("update-ref", "-d", backup.Ref)
+	return err
+}
+
+func (repo) FindLatestBackup() (Backup, bool, error) {
+	out, err := run("for-each-ref", "--sort=-refname", "--format=%(refname)", "refs/gitmind/backup/")
+	if err != nil {
+		return Backup{}, false, fmt.Errorf("for-each-ref: %v", err)
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return Backup{}, false, nil
+	}
+	ref := strings.SplitN(out, "\n", 2)[0]
+	return Backup{Ref: ref}, true, nil
+}
+
+func (repo) Reset() error {
+	_, err := run("reset")
+	return err
+}
+
+func (repo) StageFiles(files []string) error {
+	for _, file := range files {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			if _, err := run("rm", file); err != nil {
+				return fmt.Errorf("file %s not found and cannot be removed: %v", file, err)
+			}
+			continue
+		}
+		if _, err := run("add", file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo) StageHunks(patch string) error {
+	return applyPatch(patch, false)
+}
+
+func (repo) UnstageHunks(patch string) error {
+	return applyPatch(patch, true)
+}
+
+func applyPatch(patch string, reverse bool) error {
+	args := []string{"apply", "--cached", "--unidiff-zero"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(patch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (repo) Commit(message string) (Hash, error) {
+	if _, err := run("commit", "-m", message); err != nil {
+		return "", err
+	}
+	out, err := run("rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return Hash(strings.TrimSpace(out)), nil
+}
+
+func run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return stdout.String(), fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+	}
+	return stdout.String(), nil
+}
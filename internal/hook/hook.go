@@ -1,57 +1,136 @@
 package hook
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/Harri200191/gitmind/internal/config"
 )
 
-const hookName = "prepare-commit-msg"
+// managedSentinel marks a hook file as one gitmind wrote, so a later
+// Install/Uninstall can tell it apart from a hook the user (or another
+// tool) authored by hand, instead of silently overwriting or deleting it.
+const managedSentinel = "# gitmind managed"
 
-func Install(repoRoot string) error {
-	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
+// Hook is one git hook gitmind knows how to install: a name (the filename
+// git looks for under .git/hooks), the script to write there, and whether
+// it applies at all given the repo's config.
+type Hook interface {
+	Name() string
+	Script() string
+	Enabled(cfg config.Config) bool
+}
+
+// allHooks lists every hook Install/Uninstall/Status manage, in the order
+// git itself fires them during a commit.
+var allHooks = []Hook{
+	preCommitHook{},
+	prepareCommitMsgHook{},
+	commitMsgHook{},
+	postCommitHook{},
+}
+
+// HookStatus reports one hook's install state, for a future
+// `gitmind hook status` command.
+type HookStatus struct {
+	Name      string
+	Installed bool
+	// Managed is true when Installed and the file carries managedSentinel
+	// -- i.e. gitmind itself wrote it, as opposed to a foreign hook sharing
+	// the same filename.
+	Managed bool
+	// HasLocal is true when <name>.local exists: a pre-existing hook
+	// installHook preserved and chains from the managed wrapper.
+	HasLocal bool
+}
 
+// Install writes every hook enabled under cfg into repoRoot's
+// .git/hooks, idempotently: a hook already carrying managedSentinel is
+// simply overwritten, while an existing foreign script is renamed to
+// <name>.local and chained from the generated wrapper instead of being
+// discarded.
+func Install(repoRoot string, cfg config.Config) error {
+	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
 	if err := os.MkdirAll(hooksDir, 0755); err != nil {
 		return err
 	}
-	
-	hookPath := filepath.Join(hooksDir, hookName)
-	content := script()
-	
-	if err := os.WriteFile(hookPath, []byte(content), 0755); err != nil {
-		return err
+
+	for _, h := range allHooks {
+		if !h.Enabled(cfg) {
+			continue
+		}
+		if err := installHook(hooksDir, h); err != nil {
+			return fmt.Errorf("installing %s hook: %v", h.Name(), err)
+		}
+		fmt.Printf("✅ Installed %s hook\n", h.Name())
 	}
+	return nil
+}
 
-	fmt.Printf("✅ Installed %s hook\n", hookName)
- 
-	if err := os.Chmod(hookPath, 0755); err != nil {
-		return err
+func installHook(hooksDir string, h Hook) error {
+	path := filepath.Join(hooksDir, h.Name())
+
+	if existing, err := os.ReadFile(path); err == nil && !bytes.Contains(existing, []byte(managedSentinel)) {
+		localPath := path + ".local"
+		if err := os.WriteFile(localPath, existing, 0755); err != nil {
+			return fmt.Errorf("preserving existing hook as %s: %v", filepath.Base(localPath), err)
+		}
+		fmt.Printf("  chained existing %s hook as %s\n", h.Name(), filepath.Base(localPath))
 	}
 
-	return nil
+	return os.WriteFile(path, []byte(h.Script()), 0755)
 }
 
+// Uninstall removes every gitmind-managed hook from repoRoot, restoring
+// any <name>.local chained hook back to its original filename. A hook
+// file that doesn't carry managedSentinel is left alone, on the
+// assumption it's a foreign hook installed after gitmind's (e.g. by hand,
+// or by another tool) that Install never got a chance to chain.
 func Uninstall(repoRoot string) error {
-	hookPath := filepath.Join(repoRoot, ".git", "hooks", hookName)
-	if _, err := os.Stat(hookPath); err == nil {
-		return os.Remove(hookPath)
+	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
+
+	for _, h := range allHooks {
+		path := filepath.Join(hooksDir, h.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(data, []byte(managedSentinel)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		localPath := path + ".local"
+		if _, err := os.Stat(localPath); err == nil {
+			if err := os.Rename(localPath, path); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-func script() string {
-    return `#!/usr/bin/env bash
-set -euo pipefail
-MSG_FILE="$1"
-
-if ! command -v gitmind >/dev/null 2>&1; then
-    echo "⚠️  gitmind not found, skipping commit message generation"
-    exit 0
-fi
-
-if ! gitmind generate -f "$MSG_FILE"; then
-    echo "❌ gitmind failed to generate commit message" >&2
-    exit 1
-fi
-`
+// Status reports the install state of every hook gitmind manages, for a
+// future `gitmind hook status` command.
+func Status(repoRoot string) ([]HookStatus, error) {
+	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
+
+	statuses := make([]HookStatus, 0, len(allHooks))
+	for _, h := range allHooks {
+		path := filepath.Join(hooksDir, h.Name())
+		st := HookStatus{Name: h.Name()}
+
+		if data, err := os.ReadFile(path); err == nil {
+			st.Installed = true
+			st.Managed = bytes.Contains(data, []byte(managedSentinel))
+		}
+		if _, err := os.Stat(path + ".local"); err == nil {
+			st.HasLocal = true
+		}
+
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
 }
@@ -0,0 +1,109 @@
+package hook
+
+import (
+	"fmt"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// wrapperScript renders name's managed hook script: the shebang and
+// managedSentinel installHook looks for, a chain to <name>.local if one
+// was preserved there, then body -- the hook-specific logic.
+func wrapperScript(name, body string) string {
+	return fmt.Sprintf(`#!/usr/bin/env bash
+%s
+set -euo pipefail
+
+if [ -x "$(dirname "$0")/%s.local" ]; then
+    "$(dirname "$0")/%s.local" "$@" || exit $?
+fi
+
+%s`, managedSentinel, name, name, body)
+}
+
+// preCommitHook runs the Security subsystem against staged changes and
+// aborts the commit on a high-severity finding, before generate ever
+// makes an LLM call.
+type preCommitHook struct{}
+
+func (preCommitHook) Name() string { return "pre-commit" }
+
+func (preCommitHook) Enabled(cfg config.Config) bool { return cfg.Security.Enabled }
+
+func (preCommitHook) Script() string {
+	return wrapperScript("pre-commit", `if ! command -v gitmind >/dev/null 2>&1; then
+    echo "⚠️  gitmind not found, skipping security check" >&2
+    exit 0
+fi
+
+if ! gitmind security-check -block; then
+    echo "❌ gitmind blocked this commit due to security findings" >&2
+    exit 1
+fi
+`)
+}
+
+// prepareCommitMsgHook generates a commit message for an empty commit-msg
+// file -- gitmind's original hook, now one of several.
+type prepareCommitMsgHook struct{}
+
+func (prepareCommitMsgHook) Name() string { return "prepare-commit-msg" }
+
+func (prepareCommitMsgHook) Enabled(cfg config.Config) bool { return true }
+
+func (prepareCommitMsgHook) Script() string {
+	return wrapperScript("prepare-commit-msg", `MSG_FILE="$1"
+
+if ! command -v gitmind >/dev/null 2>&1; then
+    echo "⚠️  gitmind not found, skipping commit message generation"
+    exit 0
+fi
+
+if ! gitmind generate -f "$MSG_FILE"; then
+    echo "❌ gitmind failed to generate commit message" >&2
+    exit 1
+fi
+`)
+}
+
+// commitMsgHook validates the final commit message -- whether gitmind
+// generated it or the user wrote it by hand -- against cfg.Style.
+type commitMsgHook struct{}
+
+func (commitMsgHook) Name() string { return "commit-msg" }
+
+func (commitMsgHook) Enabled(cfg config.Config) bool { return true }
+
+func (commitMsgHook) Script() string {
+	return wrapperScript("commit-msg", `MSG_FILE="$1"
+
+if ! command -v gitmind >/dev/null 2>&1; then
+    echo "⚠️  gitmind not found, skipping commit message validation"
+    exit 0
+fi
+
+if ! gitmind validate-message -f "$MSG_FILE"; then
+    echo "❌ commit message failed style validation" >&2
+    exit 1
+fi
+`)
+}
+
+// postCommitHook optionally generates tests for the functions the
+// just-made commit touched. Failures here are logged, not fatal -- the
+// commit already happened, so there's nothing left to block.
+type postCommitHook struct{}
+
+func (postCommitHook) Name() string { return "post-commit" }
+
+func (postCommitHook) Enabled(cfg config.Config) bool { return cfg.TestGeneration.Enabled }
+
+func (postCommitHook) Script() string {
+	return wrapperScript("post-commit", `if ! command -v gitmind >/dev/null 2>&1; then
+    echo "⚠️  gitmind not found, skipping test generation" >&2
+    exit 0
+fi
+
+gitmind suggest-tests -last-commit -stage || true
+`)
+}
@@ -0,0 +1,79 @@
+package hook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// conventionalSubjectRe matches a Conventional Commits 1.0 subject line,
+// e.g. "feat(parser): handle trailing commas" or "fix!: drop legacy flag".
+var conventionalSubjectRe = regexp.MustCompile(`^[a-z]+(\([a-zA-Z0-9/_.-]+\))?!?: .+`)
+
+// subjectLineLimit and bodyWrapWidth match the 72-column convention
+// HeuristicMessage and llama.generatePlaceholder already truncate a
+// generated subject to.
+const (
+	subjectLineLimit = 72
+	bodyWrapWidth    = 72
+)
+
+// ValidateMessage checks message -- the final commit message, exactly as
+// git hands it to the commit-msg hook -- against cfg.Style, returning one
+// description per violation so the hook can report everything wrong at
+// once instead of stopping at the first.
+func ValidateMessage(cfg config.Config, message string) []string {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+
+	subject := lines[0]
+	if strings.TrimSpace(subject) == "" {
+		return []string{"commit message is empty"}
+	}
+
+	var problems []string
+
+	if cfg.Style == "conventional" && !conventionalSubjectRe.MatchString(subject) {
+		problems = append(problems, fmt.Sprintf(
+			"subject %q doesn't follow Conventional Commits style (expected \"type(scope): description\")", subject))
+	}
+	if len(subject) > subjectLineLimit {
+		problems = append(problems, fmt.Sprintf(
+			"subject is %d characters, exceeds the %d-character limit", len(subject), subjectLineLimit))
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "second line must be blank, separating subject from body")
+	}
+
+	maxBodyLines := cfg.MaxSummaryLines
+	if maxBodyLines <= 0 {
+		maxBodyLines = 15
+	}
+
+	bodyLines := 0
+	for i, line := range lines[min(2, len(lines)):] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		bodyLines++
+		if len(line) > bodyWrapWidth {
+			problems = append(problems, fmt.Sprintf(
+				"body line %d is %d characters, exceeds the %d-column wrap width", i+3, len(line), bodyWrapWidth))
+		}
+	}
+	if bodyLines > maxBodyLines {
+		problems = append(problems, fmt.Sprintf(
+			"body has %d lines, exceeds max_summary_lines (%d)", bodyLines, maxBodyLines))
+	}
+
+	return problems
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
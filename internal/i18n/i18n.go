@@ -0,0 +1,106 @@
+// Package i18n translates gitmind's user-facing CLI output. T's key is
+// gitmind's canonical English message, the same string a caller would
+// otherwise have passed straight to fmt.Printf -- so a call site reads
+// the same whether or not a translation exists, and a catalog without an
+// entry for some key degrades to English instead of a blank string.
+//
+// Catalogs are embedded JSON maps (key: canonical English, value: the
+// locale's translation) under catalogs/, one file per BCP-47 language
+// subtag (e.g. catalogs/fr.json). The active locale is resolved once at
+// package init from GITMIND_LANG, falling back to LANG, and can be
+// switched at runtime with SetLocale for tests or a --lang flag.
+//
+// New locales are added by dropping a catalogs/<lang>.json file -- no Go
+// source changes needed. `go run ./cmd/xstrings` walks the repo's AST for
+// T(...) calls and writes po/default.pot, the list of keys a translator
+// needs to cover, so catalogs can be kept in sync with the source without
+// grepping for every T( call by hand.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:embed catalogs/*.json
+var catalogFS embed.FS
+
+type catalog map[string]string
+
+var (
+	active catalog
+	loaded = map[string]catalog{}
+)
+
+func init() {
+	SetLocale(detectLocale())
+}
+
+// detectLocale resolves the startup locale from GITMIND_LANG, falling
+// back to the POSIX LANG variable, trimming either down to its base
+// language subtag (e.g. "fr_FR.UTF-8" -> "fr"). Neither set means "en".
+func detectLocale() string {
+	for _, env := range []string{"GITMIND_LANG", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return baseLocale(v)
+		}
+	}
+	return "en"
+}
+
+func baseLocale(v string) string {
+	v = strings.SplitN(v, ".", 2)[0]
+	v = strings.SplitN(v, "_", 2)[0]
+	v = strings.SplitN(v, "-", 2)[0]
+	return strings.ToLower(v)
+}
+
+// SetLocale switches the active catalog to locale. A locale with no
+// embedded catalog falls back to "en"; "en" itself falls back to an empty
+// catalog, so T still works (returning its key verbatim) even if
+// catalogs/en.json were ever missing.
+func SetLocale(locale string) {
+	if c, ok := loaded[locale]; ok {
+		active = c
+		return
+	}
+	c, err := loadCatalog(locale)
+	if err != nil {
+		if locale != "en" {
+			SetLocale("en")
+			return
+		}
+		c = catalog{}
+	}
+	loaded[locale] = c
+	active = c
+}
+
+func loadCatalog(locale string) (catalog, error) {
+	data, err := catalogFS.ReadFile("catalogs/" + locale + ".json")
+	if err != nil {
+		return nil, err
+	}
+	var c catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("i18n: catalogs/%s.json: %w", locale, err)
+	}
+	return c, nil
+}
+
+// T translates key -- gitmind's canonical English message -- into the
+// active locale, then applies args with fmt.Sprintf exactly as key itself
+// would be used with Printf.
+func T(key string, args ...any) string {
+	msg, ok := active[key]
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
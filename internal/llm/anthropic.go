@@ -0,0 +1,191 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+const anthropicDefaultURL = "https://api.anthropic.com/v1"
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	Messages    []anthropicMessage `json:"messages"`
+	Temperature float32            `json:"temperature,omitempty"`
+	TopP        float32            `json:"top_p,omitempty"`
+	MaxTokens   int                `json:"max_tokens"`
+	Stream      bool               `json:"stream"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Text string `json:"text"`
+	} `json:"content"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// anthropicStreamEvent covers the subset of the Messages API's SSE events
+// needed to reassemble the reply: "content_block_delta" carries the next
+// fragment of text, everything else is ignored.
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type anthropicProvider struct {
+	cfg     config.Config
+	baseURL string
+	apiKey  string
+}
+
+func newAnthropicProvider(cfg config.Config) Provider {
+	baseURL := cfg.Model.BaseURL
+	if baseURL == "" {
+		baseURL = anthropicDefaultURL
+	}
+	return &anthropicProvider{cfg: cfg, baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: os.Getenv("ANTHROPIC_API_KEY")}
+}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+func (p *anthropicProvider) Doctor(ctx context.Context) (bool, string) {
+	if p.cfg.Model.ModelPath == "" {
+		return false, "model_path not set for Anthropic"
+	}
+	if p.apiKey == "" {
+		return false, "ANTHROPIC_API_KEY not set"
+	}
+	return true, fmt.Sprintf("Anthropic: %s", p.cfg.Model.ModelPath)
+}
+
+func (p *anthropicProvider) request(ctx context.Context, prompt string, opts GenerateOptions, stream bool) (*http.Response, error) {
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 1024
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:       opts.Model,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   maxTokens,
+		Stream:      stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/messages", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("x-api-key", p.apiKey)
+
+	client := &http.Client{Timeout: 5 * 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Anthropic API: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var errResp anthropicResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Error != nil {
+			return nil, fmt.Errorf("anthropic api error: %s", errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("anthropic api returned status %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (p *anthropicProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	resp, err := p.request(ctx, prompt, opts, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var msgResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&msgResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(msgResp.Content) == 0 {
+		return "", fmt.Errorf("anthropic api returned no content")
+	}
+
+	var sb strings.Builder
+	for _, block := range msgResp.Content {
+		sb.WriteString(block.Text)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// GenerateStream consumes the Messages API's SSE stream, forwarding each
+// content_block_delta fragment until the connection closes.
+func (p *anthropicProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error) {
+	resp, err := p.request(ctx, prompt, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				tokens <- Token{Err: fmt.Errorf("failed to decode stream event: %w", err)}
+				return
+			}
+			if event.Error != nil {
+				tokens <- Token{Err: fmt.Errorf("anthropic api error: %s", event.Error.Message)}
+				return
+			}
+			if event.Type != "content_block_delta" || event.Delta.Text == "" {
+				continue
+			}
+			select {
+			case tokens <- Token{Text: event.Delta.Text}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			tokens <- Token{Err: fmt.Errorf("failed to read stream: %w", err)}
+		}
+	}()
+
+	return tokens, nil
+}
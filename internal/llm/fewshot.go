@@ -0,0 +1,164 @@
+package llm
+
+import (
+	"bytes"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+const (
+	fewShotRecordSep = "\x1e"
+	fewShotFieldSep  = "\x1f"
+)
+
+// FewShotExample is one "diff -> commit message" pair pulled from the
+// repo's own history, used to teach buildPrompt the project's voice
+// (conventional-commit prefixes, tense, ticket references) without
+// hand-written Rules.
+type FewShotExample struct {
+	Diff    string
+	Subject string
+	Body    string
+}
+
+// collectFewShotExamples walks recent history for cfg.Prompt.FewShot's
+// PathFilter (or, absent one, the files touched by the current diff) and
+// returns up to Count examples, newest first, skipping merge commits and
+// subjects shorter than MinMessageLen.
+//
+// NOTE: once github.com/go-git/go-git/v5 is vendored this should walk
+// plumbing/object.CommitIter directly instead of shelling out to `git
+// log`/`git show`; until then it follows diff.Staged's convention of
+// invoking the git binary. `gitmind doctor`'s "Deferred library
+// integrations" section reports this gap too.
+func collectFewShotExamples(cfg config.Config, files []string) []FewShotExample {
+	fs := cfg.Prompt.FewShot
+	if !fs.Enabled || fs.Count <= 0 || len(files) == 0 {
+		return nil
+	}
+
+	pathspecs := fs.PathFilter
+	if len(pathspecs) == 0 {
+		pathspecs = files
+	}
+
+	// Over-fetch: merge commits and short subjects get filtered out below,
+	// so ask git log for a few times Count to have enough left over.
+	args := []string{
+		"log", "--no-merges",
+		"-n", strconv.Itoa(fs.Count * 3),
+		"--format=" + fewShotRecordSep + "%H" + fewShotFieldSep + "%s" + fewShotFieldSep + "%b",
+		"--",
+	}
+	args = append(args, pathspecs...)
+
+	cmd := exec.Command("git", args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+
+	minLen := fs.MinMessageLen
+	if minLen <= 0 {
+		minLen = 10
+	}
+	maxBytes := fs.MaxDiffBytes
+	if maxBytes <= 0 {
+		maxBytes = 2000
+	}
+
+	var examples []FewShotExample
+	for _, rec := range strings.Split(out.String(), fewShotRecordSep) {
+		if len(examples) >= fs.Count {
+			break
+		}
+		rec = strings.TrimSpace(rec)
+		if rec == "" {
+			continue
+		}
+
+		parts := strings.SplitN(rec, fewShotFieldSep, 3)
+		if len(parts) < 2 {
+			continue
+		}
+		hash, subject := parts[0], strings.TrimSpace(parts[1])
+		if len(subject) < minLen {
+			continue
+		}
+		body := ""
+		if len(parts) == 3 {
+			body = strings.TrimSpace(parts[2])
+		}
+
+		d := commitDiff(hash, maxBytes)
+		if d == "" {
+			continue
+		}
+
+		examples = append(examples, FewShotExample{Diff: d, Subject: subject, Body: body})
+	}
+
+	return examples
+}
+
+func commitDiff(hash string, maxBytes int) string {
+	cmd := exec.Command("git", "show", "--no-color", "-U0", "--format=", hash)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+
+	d := strings.TrimSpace(out.String())
+	if len(d) > maxBytes {
+		d = d[:maxBytes]
+	}
+	return d
+}
+
+// renderFewShot formats examples for injection into the prompt ahead of
+// the "Generate a commit message" instruction, one "### Example" block
+// per commit.
+func renderFewShot(examples []FewShotExample) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, ex := range examples {
+		sb.WriteString("### Example\n")
+		sb.WriteString(ex.Diff)
+		sb.WriteString("\n-> ")
+		sb.WriteString(ex.Subject)
+		if ex.Body != "" {
+			sb.WriteString("\n")
+			sb.WriteString(ex.Body)
+		}
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// changedFilesFromDiff extracts the post-image paths touched by a unified
+// diff, in first-seen order, so few-shot history lookup can scope itself
+// to the files actually changed.
+func changedFilesFromDiff(diff string) []string {
+	seen := make(map[string]struct{})
+	var files []string
+	for _, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "+++ b/") {
+			continue
+		}
+		f := strings.TrimPrefix(line, "+++ b/")
+		if _, ok := seen[f]; ok {
+			continue
+		}
+		seen[f] = struct{}{}
+		files = append(files, f)
+	}
+	return files
+}
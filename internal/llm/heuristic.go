@@ -0,0 +1,46 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// heuristicProvider is the no-model fallback: a deterministic summary
+// built purely from the changed files a diff mentions, with no network
+// call and nothing to configure. Selectable directly as
+// cfg.Model.Provider = "heuristic", it's also what diff.HeuristicMessage
+// does outside the Provider abstraction for callers with the LLM
+// disabled entirely -- this copy exists so a cluster that does want LLM
+// involvement can still go through the same Provider interface as every
+// other backend instead of a special case.
+type heuristicProvider struct{}
+
+func newHeuristicProvider(cfg config.Config) Provider { return heuristicProvider{} }
+
+func (heuristicProvider) Name() string { return "heuristic" }
+
+func (heuristicProvider) Doctor(ctx context.Context) (bool, string) {
+	return true, "no external dependency"
+}
+
+func (heuristicProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	files := changedFilesFromDiff(prompt)
+	switch len(files) {
+	case 0:
+		return "chore: update changes", nil
+	case 1:
+		return fmt.Sprintf("chore: update %s", files[0]), nil
+	default:
+		return fmt.Sprintf("chore: update %d files", len(files)), nil
+	}
+}
+
+func (p heuristicProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error) {
+	message, _ := p.Generate(ctx, prompt, opts)
+	ch := make(chan Token, 1)
+	ch <- Token{Text: message}
+	close(ch)
+	return ch, nil
+}
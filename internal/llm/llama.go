@@ -0,0 +1,47 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/llm/llama"
+)
+
+// llamaProvider wraps internal/llm/llama -- still a placeholder pending
+// real cgo bindings, per that package's own TODOs -- behind the same
+// Provider interface every HTTP-backed provider implements, so
+// cfg.Model.Provider = "llama.cpp" (gitmind's own config default)
+// resolves to something instead of erroring out as unsupported.
+type llamaProvider struct {
+	cfg config.Config
+}
+
+func newLlamaProvider(cfg config.Config) Provider { return llamaProvider{cfg: cfg} }
+
+func (llamaProvider) Name() string { return "llama.cpp" }
+
+func (p llamaProvider) Doctor(ctx context.Context) (bool, string) {
+	if _, err := os.Stat(p.cfg.Model.ModelPath); err != nil {
+		return false, fmt.Sprintf("model file not found: %s", p.cfg.Model.ModelPath)
+	}
+	return true, fmt.Sprintf("model file present at %s (placeholder generation -- cgo bindings not yet vendored)", p.cfg.Model.ModelPath)
+}
+
+func (p llamaProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	return llama.Generate(p.cfg, prompt)
+}
+
+func (p llamaProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error) {
+	message, err := p.Generate(ctx, prompt, opts)
+	ch := make(chan Token, 1)
+	if err != nil {
+		ch <- Token{Err: err}
+		close(ch)
+		return ch, err
+	}
+	ch <- Token{Text: message}
+	close(ch)
+	return ch, nil
+}
@@ -1,145 +1,163 @@
-package llm
-
-import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
-	"strings"
-	"time"
-
-	"github.com/Harri200191/gitmind/internal/config"
-)
-
-func Doctor(cfg config.Config) (bool, string) {
-	if !cfg.Model.Enabled {
-		return false, "model disabled in config"
-	}
-
-	switch strings.ToLower(cfg.Model.Provider) {
-	case "ollama":
-		// Check if Ollama is running and model is available
-		if err := checkOllamaHealth(); err != nil {
-			return false, fmt.Sprintf("Ollama not accessible: %v", err)
-		}
-		if cfg.Model.ModelPath == "" {
-			return false, "model_path not set for Ollama"
-		}
-		return true, fmt.Sprintf("Ollama: %s", cfg.Model.ModelPath)
-	default:
-		return false, fmt.Sprintf("unsupported provider: %s", cfg.Model.Provider)
-	}
-}
-
-func Generate(cfg config.Config, diff string) (string, error) {
-	if !cfg.Model.Enabled {
-		return "", errors.New("model disabled")
-	}
-	switch strings.ToLower(cfg.Model.Provider) {
-	case "ollama":
-		return generateWithOllama(cfg, diff) 
-	default:
-		return "", errors.New("unsupported provider: " + cfg.Model.Provider)
-	}
-}
-
-// OllamaRequest represents the request structure for Ollama API
-type OllamaRequest struct {
-	Model   string                 `json:"model"`
-	Prompt  string                 `json:"prompt"`
-	Stream  bool                   `json:"stream"`
-	Options map[string]interface{} `json:"options,omitempty"`
-}
-
-// OllamaResponse represents the response structure from Ollama API
-type OllamaResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
-	Error    string `json:"error,omitempty"`
-}
-
-func checkOllamaHealth() error {
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Get("http://localhost:11434/api/tags")
-	if err != nil {
-		return fmt.Errorf("failed to connect to Ollama: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("ollama returned status %d", resp.StatusCode)
-	}
-
-	return nil
-}
-
-func generateWithOllama(cfg config.Config, diff string) (string, error) {
-	prompt := buildPrompt(cfg, diff)
-
-	req := OllamaRequest{
-		Model:  cfg.Model.ModelPath,
-		Prompt: prompt,
-		Stream: false,
-		Options: map[string]interface{}{
-			"temperature": cfg.Model.Temp,
-			"top_p":       cfg.Model.TopP,
-			"num_predict": cfg.Model.MaxTokens,
-		},
-	}
-
-	reqBody, err := json.Marshal(req)
-
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	client := &http.Client{Timeout: 5 * 60 * time.Second}
-	resp, err := client.Post("http://localhost:11434/api/generate", "application/json", bytes.NewBuffer(reqBody))
-
-	if err != nil {
-		return "", fmt.Errorf("failed to call Ollama API: %w", err)
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("ollama api returned status %d", resp.StatusCode)
-	}
-
-	var ollamaResp OllamaResponse
-
-	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if ollamaResp.Error != "" {
-		return "", fmt.Errorf("ollama error: %s", ollamaResp.Error)
-	}
-
-	return strings.TrimSpace(ollamaResp.Response), nil
-}
-
-func buildPrompt(cfg config.Config, diff string) string {
-	var prompt strings.Builder
-
-	// Add preface
-	if cfg.Prompt.Preface != "" {
-		prompt.WriteString(cfg.Prompt.Preface)
-		prompt.WriteString("\n\n")
-	}
-
-	// Add rules
-	if cfg.Prompt.Rules != "" {
-		prompt.WriteString("Rules:\n")
-		prompt.WriteString(cfg.Prompt.Rules)
-		prompt.WriteString("\n\n")
-	}
-
-	// Add the task
-	prompt.WriteString("Generate a commit message for the following git diff:\n\n")
-	prompt.WriteString(diff)
-	prompt.WriteString("\n\nCommit message:")
-
-	return prompt.String()
-}
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/splitter"
+)
+
+func Doctor(ctx context.Context, cfg config.Config) (bool, string) {
+	if !cfg.Model.Enabled {
+		return false, "model disabled in config"
+	}
+
+	provider, err := resolveProvider(cfg)
+	if err != nil {
+		return false, err.Error()
+	}
+	return provider.Doctor(ctx)
+}
+
+// Generate builds the prompt for diff, dispatches it to the configured
+// provider, and returns the resulting commit message. When stdout is an
+// interactive terminal, it streams tokens there as they arrive (so the
+// user isn't staring at a blank screen during a slow model call) while
+// still returning the fully assembled message once the stream ends.
+func Generate(ctx context.Context, cfg config.Config, diff string) (string, error) {
+	if !cfg.Model.Enabled {
+		return "", errors.New("model disabled")
+	}
+
+	provider, err := resolveProvider(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	prompt := buildPrompt(cfg, diff)
+	opts := optionsFromConfig(cfg)
+
+	if !isInteractive(os.Stdout) {
+		message, err := provider.Generate(ctx, prompt, opts)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", strings.ToLower(cfg.Model.Provider), err)
+		}
+		return message, nil
+	}
+
+	tokens, err := provider.GenerateStream(ctx, prompt, opts)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.ToLower(cfg.Model.Provider), err)
+	}
+
+	var sb strings.Builder
+	for tok := range tokens {
+		if tok.Err != nil {
+			return "", fmt.Errorf("%s: %w", strings.ToLower(cfg.Model.Provider), tok.Err)
+		}
+		fmt.Fprint(os.Stdout, tok.Text)
+		sb.WriteString(tok.Text)
+	}
+	fmt.Fprintln(os.Stdout)
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// isInteractive reports whether f looks like a terminal rather than a pipe
+// or redirected file, using only the stdlib (no golang.org/x/term dependency).
+func isInteractive(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// symbolsChangedSection renders the distinct functions/methods/types changes
+// touch as a "Symbols changed:" line, giving the model a precise summary of
+// intent beyond the raw +/- text.
+func symbolsChangedSection(changes []splitter.Change) string {
+	seen := make(map[string]struct{})
+	var names []string
+	for _, change := range changes {
+		for _, fn := range change.Functions {
+			if _, ok := seen[fn]; ok {
+				continue
+			}
+			seen[fn] = struct{}{}
+			names = append(names, fn)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	return "Symbols changed: " + strings.Join(names, ", ") + "\n\n"
+}
+
+// blameSection surfaces AnalyzeDiff's optional blame pass (MultiCommit.UseBlame)
+// as one line per change whose removed lines trace back to a prior commit,
+// e.g. "internal/llm/llm.go: 3 line(s) last touched by \"add retry loop\"
+// (2 week(s) ago)" -- a strong hint the model should write a revert/refactor
+// message rather than a generic "update" one.
+func blameSection(changes []splitter.Change) string {
+	var notes []string
+	for _, change := range changes {
+		attributions, ok := change.Metadata["blame"].([]splitter.BlameAttribution)
+		if !ok || len(attributions) == 0 {
+			continue
+		}
+		top := attributions[0]
+		notes = append(notes, fmt.Sprintf("%s: %d line(s) last touched by %q (%s)",
+			strings.Join(change.Files, ", "), top.Lines, top.Subject, top.Age))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+
+	return "Blame context:\n" + strings.Join(notes, "\n") + "\n\n"
+}
+
+func buildPrompt(cfg config.Config, diff string) string {
+	var prompt strings.Builder
+
+	// Add preface
+	if cfg.Prompt.Preface != "" {
+		prompt.WriteString(cfg.Prompt.Preface)
+		prompt.WriteString("\n\n")
+	}
+
+	// Add rules
+	if cfg.Prompt.Rules != "" {
+		prompt.WriteString("Rules:\n")
+		prompt.WriteString(cfg.Prompt.Rules)
+		prompt.WriteString("\n\n")
+	}
+
+	// Few-shot examples from the project's own history
+	if examples := collectFewShotExamples(cfg, changedFilesFromDiff(diff)); len(examples) > 0 {
+		prompt.WriteString(renderFewShot(examples))
+	}
+
+	// Symbols touched and (if enabled) blame attribution, both derived from
+	// a single splitter analysis pass rather than reparsing the diff twice.
+	if changes, err := splitter.New(cfg).AnalyzeDiff(diff); err == nil {
+		if section := symbolsChangedSection(changes); section != "" {
+			prompt.WriteString(section)
+		}
+		if section := blameSection(changes); section != "" {
+			prompt.WriteString(section)
+		}
+	}
+
+	// Add the task
+	prompt.WriteString("Generate a commit message for the following git diff:\n\n")
+	prompt.WriteString(diff)
+	prompt.WriteString("\n\nCommit message:")
+
+	return prompt.String()
+}
@@ -0,0 +1,185 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+const ollamaDefaultURL = "http://localhost:11434"
+
+// OllamaRequest represents the request structure for Ollama API
+type OllamaRequest struct {
+	Model   string                 `json:"model"`
+	Prompt  string                 `json:"prompt"`
+	Stream  bool                   `json:"stream"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// OllamaResponse represents the response structure from Ollama API
+type OllamaResponse struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ollamaProvider struct {
+	cfg     config.Config
+	baseURL string
+}
+
+func newOllamaProvider(cfg config.Config) Provider {
+	baseURL := cfg.Model.BaseURL
+	if baseURL == "" {
+		baseURL = ollamaDefaultURL
+	}
+	return &ollamaProvider{cfg: cfg, baseURL: baseURL}
+}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+func (p *ollamaProvider) Doctor(ctx context.Context) (bool, string) {
+	if err := p.checkHealth(ctx); err != nil {
+		return false, fmt.Sprintf("Ollama not accessible: %v", err)
+	}
+	if p.cfg.Model.ModelPath == "" {
+		return false, "model_path not set for Ollama"
+	}
+	return true, fmt.Sprintf("Ollama: %s", p.cfg.Model.ModelPath)
+}
+
+func (p *ollamaProvider) checkHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/tags", nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *ollamaProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	body, err := json.Marshal(OllamaRequest{
+		Model:  opts.Model,
+		Prompt: prompt,
+		Stream: false,
+		Options: map[string]interface{}{
+			"temperature": opts.Temperature,
+			"top_p":       opts.TopP,
+			"num_predict": opts.MaxTokens,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/generate", bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 5 * 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call Ollama API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ollama api returned status %d", resp.StatusCode)
+	}
+
+	var ollamaResp OllamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if ollamaResp.Error != "" {
+		return "", fmt.Errorf("ollama error: %s", ollamaResp.Error)
+	}
+
+	return strings.TrimSpace(ollamaResp.Response), nil
+}
+
+// GenerateStream requests /api/generate with stream:true and forwards each
+// decoded "response" fragment as a Token until Ollama reports done:true.
+func (p *ollamaProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error) {
+	body, err := json.Marshal(OllamaRequest{
+		Model:  opts.Model,
+		Prompt: prompt,
+		Stream: true,
+		Options: map[string]interface{}{
+			"temperature": opts.Temperature,
+			"top_p":       opts.TopP,
+			"num_predict": opts.MaxTokens,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/generate", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Ollama API: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ollama api returned status %d", resp.StatusCode)
+	}
+
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var chunk OllamaResponse
+			if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+				tokens <- Token{Err: fmt.Errorf("failed to decode stream chunk: %w", err)}
+				return
+			}
+			if chunk.Error != "" {
+				tokens <- Token{Err: fmt.Errorf("ollama error: %s", chunk.Error)}
+				return
+			}
+			if chunk.Response != "" {
+				select {
+				case tokens <- Token{Text: chunk.Response}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if chunk.Done {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			tokens <- Token{Err: fmt.Errorf("failed to read stream: %w", err)}
+		}
+	}()
+
+	return tokens, nil
+}
@@ -0,0 +1,179 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+const openAIDefaultURL = "https://api.openai.com/v1"
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model       string              `json:"model"`
+	Messages    []openAIChatMessage `json:"messages"`
+	Temperature float32             `json:"temperature,omitempty"`
+	TopP        float32             `json:"top_p,omitempty"`
+	MaxTokens   int                 `json:"max_tokens,omitempty"`
+	Stream      bool                `json:"stream"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+		Delta   openAIChatMessage `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// openaiProvider speaks the OpenAI chat-completions API. Setting
+// cfg.Model.BaseURL repoints it at any OpenAI-compatible server (vLLM,
+// llama.cpp's `server` mode, LM Studio, ...) without changing the wire
+// format.
+type openaiProvider struct {
+	cfg     config.Config
+	baseURL string
+	apiKey  string
+}
+
+func newOpenAIProvider(cfg config.Config) Provider {
+	baseURL := cfg.Model.BaseURL
+	if baseURL == "" {
+		baseURL = openAIDefaultURL
+	}
+	return &openaiProvider{cfg: cfg, baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: os.Getenv("OPENAI_API_KEY")}
+}
+
+func (p *openaiProvider) Name() string { return "openai" }
+
+func (p *openaiProvider) Doctor(ctx context.Context) (bool, string) {
+	if p.cfg.Model.ModelPath == "" {
+		return false, "model_path not set for OpenAI"
+	}
+	if p.apiKey == "" && p.baseURL == openAIDefaultURL {
+		return false, "OPENAI_API_KEY not set"
+	}
+	return true, fmt.Sprintf("OpenAI: %s (%s)", p.cfg.Model.ModelPath, p.baseURL)
+}
+
+func (p *openaiProvider) request(ctx context.Context, prompt string, opts GenerateOptions, stream bool) (*http.Response, error) {
+	body, err := json.Marshal(openAIChatRequest{
+		Model:       opts.Model,
+		Messages:    []openAIChatMessage{{Role: "user", Content: prompt}},
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   opts.MaxTokens,
+		Stream:      stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	client := &http.Client{Timeout: 5 * 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call OpenAI API: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var errResp openAIChatResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Error != nil {
+			return nil, fmt.Errorf("openai api error: %s", errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("openai api returned status %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (p *openaiProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	resp, err := p.request(ctx, prompt, opts, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var chatResp openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("openai api returned no choices")
+	}
+
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+}
+
+// GenerateStream consumes the `text/event-stream` response OpenAI-compatible
+// chat endpoints send when stream:true, forwarding each delta.content
+// fragment until a "[DONE]" sentinel line closes the stream.
+func (p *openaiProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error) {
+	resp, err := p.request(ctx, prompt, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(chan Token)
+	go func() {
+		defer close(tokens)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "[DONE]" {
+				return
+			}
+
+			var chunk openAIChatResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				tokens <- Token{Err: fmt.Errorf("failed to decode stream chunk: %w", err)}
+				return
+			}
+			if chunk.Error != nil {
+				tokens <- Token{Err: fmt.Errorf("openai api error: %s", chunk.Error.Message)}
+				return
+			}
+			if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+				continue
+			}
+			select {
+			case tokens <- Token{Text: chunk.Choices[0].Delta.Content}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			tokens <- Token{Err: fmt.Errorf("failed to read stream: %w", err)}
+		}
+	}()
+
+	return tokens, nil
+}
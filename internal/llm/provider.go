@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// GenerateOptions carries the sampling parameters every provider accepts,
+// translated from config.Model so individual providers don't each reach
+// into config.Config directly.
+type GenerateOptions struct {
+	Model       string
+	Temperature float32
+	TopP        float32
+	MaxTokens   int
+}
+
+// Token is one fragment of a streamed generation. Err is set (with Text
+// empty) on the final value sent before the channel is closed if the
+// stream failed partway through; callers should stop reading on either
+// a non-nil Err or the channel closing.
+type Token struct {
+	Text string
+	Err  error
+}
+
+// Provider is a backend capable of turning a prompt into a commit message,
+// either all at once or token-by-token. Doctor reports whether the backend
+// is reachable and configured correctly, mirroring the top-level
+// llm.Doctor contract. Name identifies the provider in doctor output and
+// error messages, matching the registry key it was registered under.
+type Provider interface {
+	Name() string
+	Doctor(ctx context.Context) (bool, string)
+	Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error)
+	GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan Token, error)
+}
+
+// factory builds a Provider from config, deferring URL/credential lookup
+// until a provider is actually selected.
+type factory func(cfg config.Config) Provider
+
+var providers = map[string]factory{}
+
+func registerProvider(name string, f factory) {
+	providers[name] = f
+}
+
+func init() {
+	registerProvider("ollama", newOllamaProvider)
+	registerProvider("openai", newOpenAIProvider)
+	registerProvider("anthropic", newAnthropicProvider)
+	registerProvider("llama.cpp", newLlamaProvider)
+	registerProvider("llama", newLlamaProvider)
+	registerProvider("heuristic", newHeuristicProvider)
+}
+
+// resolveProvider looks up the configured provider by name (case
+// insensitive, matching the existing cfg.Model.Provider convention).
+func resolveProvider(cfg config.Config) (Provider, error) {
+	name := strings.ToLower(cfg.Model.Provider)
+	f, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider: %s", cfg.Model.Provider)
+	}
+	return f(cfg), nil
+}
+
+// DoctorAll runs every registered provider's Doctor check against cfg,
+// keyed by provider name, regardless of which one cfg.Model.Provider
+// currently selects -- so `gitmind doctor` can report on every backend's
+// reachability (endpoint up, model pulled, API key present) at once,
+// not just the one that's active.
+func DoctorAll(ctx context.Context, cfg config.Config) map[string]string {
+	results := make(map[string]string, len(providers))
+	for name, f := range providers {
+		ok, info := f(cfg).Doctor(ctx)
+		status := "ready"
+		if !ok {
+			status = "not ready"
+		}
+		results[name] = fmt.Sprintf("%s: %s", status, info)
+	}
+	return results
+}
+
+func optionsFromConfig(cfg config.Config) GenerateOptions {
+	return GenerateOptions{
+		Model:       cfg.Model.ModelPath,
+		Temperature: cfg.Model.Temp,
+		TopP:        cfg.Model.TopP,
+		MaxTokens:   cfg.Model.MaxTokens,
+	}
+}
@@ -0,0 +1,101 @@
+package patch
+
+import (
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/gitx"
+)
+
+// newRepo builds the Repo a Manager stages/unstages through. A package
+// var, like splitter's goAnalyzer and newRepo, so a test can swap in an
+// in-memory fake instead of shelling out to a real git repo.
+var newRepo = gitx.New
+
+// Manager is the patch manager: it holds a selection across multiple
+// invocations -- e.g. a `gitmind stage-hunks` session building up which
+// hunks/lines to stage one at a time -- and applies or reverts it
+// against the real index.
+type Manager struct {
+	patch      *Patch
+	selections []Selection
+	repo       gitx.Repo
+}
+
+// NewManager parses diffText (typically `git diff --cached`) into a
+// Manager with an empty selection.
+func NewManager(diffText string) (*Manager, error) {
+	p, err := Parse(diffText)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{patch: p, repo: newRepo()}, nil
+}
+
+// Patch returns the Patch the Manager was built from, so a caller like
+// `gitmind stage-hunks` can list files/hunks/lines to choose from.
+func (m *Manager) Patch() *Patch {
+	return m.patch
+}
+
+// Select adds sel to the current selection, replacing any existing
+// selection for the same (File, HunkIndex) pair.
+func (m *Manager) Select(sel Selection) {
+	for i, existing := range m.selections {
+		if existing.File == sel.File && existing.HunkIndex == sel.HunkIndex {
+			m.selections[i] = sel
+			return
+		}
+	}
+	m.selections = append(m.selections, sel)
+}
+
+// Deselect removes any selection recorded for (file, hunkIndex).
+func (m *Manager) Deselect(file string, hunkIndex int) {
+	kept := m.selections[:0]
+	for _, sel := range m.selections {
+		if sel.File == file && sel.HunkIndex == hunkIndex {
+			continue
+		}
+		kept = append(kept, sel)
+	}
+	m.selections = kept
+}
+
+// Selections returns the current selection, for a caller that wants to
+// render its own summary instead of (or in addition to) Render.
+func (m *Manager) Selections() []Selection {
+	return m.selections
+}
+
+// Render is the patch modifier pass: it builds a standalone unified diff
+// from the current selection.
+func (m *Manager) Render() (string, error) {
+	return BuildPatch(m.patch, m.selections)
+}
+
+// Stage applies the current selection to the index via `git apply
+// --cached`. A selection that renders to nothing is a no-op.
+func (m *Manager) Stage() error {
+	rendered, err := m.Render()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rendered) == "" {
+		return nil
+	}
+	return m.repo.StageHunks(rendered)
+}
+
+// Unstage reverses the current selection back out of the index via `git
+// apply --cached --reverse`. A selection that renders to nothing is a
+// no-op.
+func (m *Manager) Unstage() error {
+	rendered, err := m.Render()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rendered) == "" {
+		return nil
+	}
+	return m.repo.UnstageHunks(rendered)
+}
@@ -0,0 +1,169 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selection identifies one hunk, within one file, and an optional subset
+// of that hunk's non-context lines to keep -- the unit BuildPatch
+// renders into a synthetic diff and Manager tracks across invocations.
+type Selection struct {
+	File      string
+	HunkIndex int
+	// Lines, if non-nil, is the set of indices into the hunk's Lines
+	// slice to keep among its Added/Removed lines; nil means keep every
+	// non-context line, i.e. the whole hunk. Context lines are always
+	// kept regardless of this set -- they're never individually
+	// selectable.
+	Lines map[int]bool
+}
+
+// BuildPatch is the patch modifier: it renders a standalone unified diff
+// containing exactly the hunks and lines selections pick out of p,
+// recomputing each included hunk's header and line counts. A deselected
+// "-" line is converted to context rather than dropped -- its content is
+// still unchanged between old and new, it's just no longer part of this
+// diff -- and a file with no selected hunks is omitted entirely.
+// Selecting any line of a binary file's diff is an error; binary content
+// isn't line-addressable.
+func BuildPatch(p *Patch, selections []Selection) (string, error) {
+	byFile := make(map[string][]Selection, len(selections))
+	for _, sel := range selections {
+		byFile[sel.File] = append(byFile[sel.File], sel)
+	}
+
+	var b strings.Builder
+	for _, fd := range p.Files {
+		sels := byFile[fd.File()]
+		if len(sels) == 0 {
+			continue
+		}
+		if fd.IsBinary {
+			return "", fmt.Errorf("cannot select lines within binary file %s", fd.File())
+		}
+		rendered, err := buildFilePatch(fd, sels)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(rendered)
+	}
+	return b.String(), nil
+}
+
+func buildFilePatch(fd FileDiff, sels []Selection) (string, error) {
+	byHunk := make(map[int]Selection, len(sels))
+	for _, sel := range sels {
+		byHunk[sel.HunkIndex] = sel
+	}
+
+	var body strings.Builder
+	within := 0
+	included := 0
+	for i, hunk := range fd.Hunks {
+		sel, ok := byHunk[i]
+		if !ok {
+			continue
+		}
+		rendered, oldLines, newLines := buildHunk(hunk, sel.Lines, within)
+		if rendered == "" {
+			continue
+		}
+		body.WriteString(rendered)
+		within += newLines - oldLines
+		included++
+	}
+	if included == 0 {
+		return "", nil
+	}
+
+	var header strings.Builder
+	file := fd.File()
+	fmt.Fprintf(&header, "diff --git a/%s b/%s\n", file, file)
+	switch {
+	case fd.IsNew:
+		mode := fd.ToMode
+		if mode == "" {
+			mode = "100644"
+		}
+		fmt.Fprintf(&header, "new file mode %s\n", mode)
+		fmt.Fprintf(&header, "--- /dev/null\n+++ b/%s\n", file)
+	case fd.IsDeleted:
+		mode := fd.FromMode
+		if mode == "" {
+			mode = "100644"
+		}
+		fmt.Fprintf(&header, "deleted file mode %s\n", mode)
+		fmt.Fprintf(&header, "--- a/%s\n+++ /dev/null\n", file)
+	default:
+		if fd.IsRename && fd.FromFile != fd.ToFile {
+			fmt.Fprintf(&header, "rename from %s\nrename to %s\n", fd.FromFile, fd.ToFile)
+		}
+		if fd.FromMode != "" && fd.ToMode != "" && fd.FromMode != fd.ToMode {
+			fmt.Fprintf(&header, "old mode %s\nnew mode %s\n", fd.FromMode, fd.ToMode)
+		}
+		fmt.Fprintf(&header, "--- a/%s\n+++ b/%s\n", fd.FromFile, file)
+	}
+
+	return header.String() + body.String(), nil
+}
+
+// buildHunk renders one hunk's selected lines, recomputing its old/new
+// line counts and header. within is the net line delta this file's
+// earlier selected hunks in the same BuildPatch call have already
+// introduced, shifting this hunk's new-side start the same way a real
+// multi-hunk diff's later hunks shift relative to its earlier ones.
+func buildHunk(hunk Hunk, keepLines map[int]bool, within int) (rendered string, oldLines, newLines int) {
+	type rendition struct {
+		prefix string
+		text   string
+	}
+	var body []rendition
+
+	for i, line := range hunk.Lines {
+		switch line.Op {
+		case Context:
+			body = append(body, rendition{" ", line.Text})
+			oldLines++
+			newLines++
+		case Added:
+			if keepLines == nil || keepLines[i] {
+				body = append(body, rendition{"+", line.Text})
+				newLines++
+			}
+		case Removed:
+			if keepLines == nil || keepLines[i] {
+				body = append(body, rendition{"-", line.Text})
+				oldLines++
+			} else {
+				// Deselected: unchanged by this selection, so it must
+				// survive as context instead of vanishing.
+				body = append(body, rendition{" ", line.Text})
+				oldLines++
+				newLines++
+			}
+		}
+	}
+
+	if len(body) == 0 {
+		return "", 0, 0
+	}
+
+	oldStart := hunk.OldStart
+	newStart := oldStart + within
+	switch {
+	case oldLines == 0:
+		newStart++
+	case newLines == 0:
+		newStart--
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLines, newStart, newLines)
+	for _, rl := range body {
+		b.WriteString(rl.prefix)
+		b.WriteString(rl.text)
+		b.WriteString("\n")
+	}
+	return b.String(), oldLines, newLines
+}
@@ -0,0 +1,135 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+)
+
+func parseOneFile(t *testing.T, diff string) FileDiff {
+	t.Helper()
+	p, err := Parse(diff)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(p.Files) != 1 {
+		t.Fatalf("expected exactly one file in diff, got %d", len(p.Files))
+	}
+	return p.Files[0]
+}
+
+func TestBuildPatchModifyOnlyHunkRecomputesHeader(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,3 @@
+ package a
+-func old() {}
++func new1() {}
++func new2() {}
+`
+	p := &Patch{Files: []FileDiff{parseOneFile(t, diff)}}
+	sels := []Selection{{File: "a.go", HunkIndex: 0}}
+
+	out, err := BuildPatch(p, sels)
+	if err != nil {
+		t.Fatalf("BuildPatch: %v", err)
+	}
+	if !strings.Contains(out, "@@ -1,2 +1,3 @@\n") {
+		t.Errorf("expected unchanged hunk header for a fully-kept hunk, got:\n%s", out)
+	}
+	if strings.Contains(out, "new file mode") || strings.Contains(out, "deleted file mode") {
+		t.Errorf("a plain modify must not carry new/deleted file headers:\n%s", out)
+	}
+}
+
+func TestBuildPatchNewFileOnlyHunk(t *testing.T) {
+	diff := `diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..1111111
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package new
++
+`
+	p := &Patch{Files: []FileDiff{parseOneFile(t, diff)}}
+	sels := []Selection{{File: "new.go", HunkIndex: 0}}
+
+	out, err := BuildPatch(p, sels)
+	if err != nil {
+		t.Fatalf("BuildPatch: %v", err)
+	}
+	if !strings.Contains(out, "new file mode 100644") || !strings.Contains(out, "--- /dev/null") {
+		t.Errorf("expected a new-file header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "@@ -0,0 +1,2 @@\n") {
+		t.Errorf("expected unchanged hunk header, got:\n%s", out)
+	}
+}
+
+func TestBuildPatchDeletionOnlyHunk(t *testing.T) {
+	diff := `diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 7777777..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-func F() {}
+`
+	p := &Patch{Files: []FileDiff{parseOneFile(t, diff)}}
+	sels := []Selection{{File: "gone.go", HunkIndex: 0}}
+
+	out, err := BuildPatch(p, sels)
+	if err != nil {
+		t.Fatalf("BuildPatch: %v", err)
+	}
+	if !strings.Contains(out, "deleted file mode 100644") || !strings.Contains(out, "+++ /dev/null") {
+		t.Errorf("expected a deleted-file header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "@@ -1,2 +0,0 @@\n") {
+		t.Errorf("expected unchanged hunk header, got:\n%s", out)
+	}
+}
+
+func TestBuildPatchDeselectedRemovedLineBecomesContext(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+ package a
+-func old1() {}
+-func old2() {}
++func new2() {}
+`
+	fd := parseOneFile(t, diff)
+	p := &Patch{Files: []FileDiff{fd}}
+
+	// The hunk's non-context lines, in order, are: Removed old1 (index 1),
+	// Removed old2 (index 2), Added new2 (index 3). Keep only old2's
+	// removal and new2's addition; old1's removal must survive as context
+	// rather than disappear, since it's unchanged by this selection.
+	keep := map[int]bool{2: true, 3: true}
+	sels := []Selection{{File: "a.go", HunkIndex: 0, Lines: keep}}
+
+	out, err := BuildPatch(p, sels)
+	if err != nil {
+		t.Fatalf("BuildPatch: %v", err)
+	}
+	if !strings.Contains(out, "\n func old1() {}\n") {
+		t.Errorf("deselected removed line must be re-emitted as context, got:\n%s", out)
+	}
+	if strings.Contains(out, "-func old1() {}") {
+		t.Errorf("deselected removed line must not still be rendered as removed, got:\n%s", out)
+	}
+	if !strings.Contains(out, "-func old2() {}") {
+		t.Errorf("kept removed line must still be rendered as removed, got:\n%s", out)
+	}
+	// old1 (context) + old2 (removed) + package a (context) = 3 old lines;
+	// package a (context) + old1 (now context) + new2 (added) = 3 new lines.
+	if !strings.Contains(out, "@@ -1,3 +1,3 @@\n") {
+		t.Errorf("expected recomputed header -1,3 +1,3, got:\n%s", out)
+	}
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/gitexec"
+)
+
+// execReader is the git-CLI-backed Reader, registered under "exec".
+type execReader struct{}
+
+func newExecReader() Reader { return execReader{} }
+
+func (execReader) StagedDiff() (string, error) {
+	return gitexec.New("diff").AddFlags("--cached", "-U0").Run(context.Background())
+}
+
+func (execReader) RangeDiff(rangeSpec string) (string, error) {
+	return gitexec.New("diff").AddFlags("-U0").AddDynamic(rangeSpec).Run(context.Background())
+}
+
+func (execReader) Blame(file, rev string) ([]BlameLine, error) {
+	out, err := gitexec.New("blame").AddFlags("--porcelain").AddDynamic(rev).AddPaths(file).Run(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("git blame %s: %w", file, err)
+	}
+	return parseBlamePorcelain(out), nil
+}
+
+func (execReader) Root() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(cwd, ".git")); err == nil {
+			return cwd, nil
+		}
+		parent := filepath.Dir(cwd)
+		if parent == cwd {
+			return "", fmt.Errorf(".git not found; run inside a repo")
+		}
+		cwd = parent
+	}
+}
+
+var blameHeaderRe = regexp.MustCompile(`^([0-9a-f]{40}) (\d+) (\d+)`)
+
+// parseBlamePorcelain decodes `git blame --porcelain` output into one
+// BlameLine per line of the blamed file. It mirrors
+// internal/splitter/blame.go's own porcelain parser, kept separate since
+// this package's BlameLine only needs Commit and Author -- crediting an
+// original author in a generated message -- not splitter's fuller
+// BlameLine (Subject, Age) used for its change-attribution heuristics.
+func parseBlamePorcelain(output string) []BlameLine {
+	var result []BlameLine
+	authorBySHA := make(map[string]string)
+
+	var curSHA, curAuthor string
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := blameHeaderRe.FindStringSubmatch(line); m != nil {
+			curSHA = m[1]
+			curAuthor = authorBySHA[curSHA]
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "author "):
+			curAuthor = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "\t"):
+			authorBySHA[curSHA] = curAuthor
+			result = append(result, BlameLine{Commit: curSHA, Author: curAuthor})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+// Package repo centralizes gitmind's read-only access to repository state
+// -- the staged diff, an arbitrary commit-range diff, blame lookups, and
+// repo-root discovery -- behind one Reader interface, selected from
+// config.Config the same way internal/llm selects a Provider.
+//
+// The only registered backend today, execReader, still shells out to the
+// git binary through internal/gitexec rather than linking
+// github.com/go-git/go-git/v5 (see the commented require in go.mod).
+// Once that's vendored, a "go-git" backend should be registered alongside
+// it that opens the repository once with go-git's
+// PlainOpenWithOptions{DetectDotGit: true} and answers every Reader
+// method from the object store directly, needing no git binary in CI or
+// containers. Reader is deliberately shaped so that addition won't touch
+// any call site. Writes (StageHunks, Commit) stay on internal/gitx, which
+// already centralizes them behind its own Repo interface -- go-git's
+// story for writing to a staged index is far less settled than its read
+// path, so this package's scope stops at reads. `gitmind doctor`'s
+// "Deferred library integrations" section reports this gap at runtime
+// too, so it isn't only discoverable by reading this comment.
+package repo
+
+import (
+	"fmt"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// BlameLine attributes one line of a blamed file to the commit that last
+// touched it, for a caller crediting the original author in a generated
+// message (e.g. "modifies code originally added by <author> in <sha>").
+type BlameLine struct {
+	Commit string
+	Author string
+}
+
+// Reader is every read-only repository query gitmind needs.
+type Reader interface {
+	// StagedDiff returns the currently staged changes, unified with zero
+	// context lines.
+	StagedDiff() (string, error)
+	// RangeDiff returns the diff across rangeSpec (e.g. "v1.2.0..HEAD" or
+	// "abc123^..abc123").
+	RangeDiff(rangeSpec string) (string, error)
+	// Blame returns one BlameLine per line of file as of rev.
+	Blame(file, rev string) ([]BlameLine, error)
+	// Root returns the repository's top-level working directory.
+	Root() (string, error)
+}
+
+// factory builds a Reader, deferring any setup (opening the repository,
+// resolving the git binary) until a backend is actually selected.
+type factory func() Reader
+
+var readers = map[string]factory{}
+
+func registerReader(name string, f factory) {
+	readers[name] = f
+}
+
+func init() {
+	registerReader("exec", newExecReader)
+}
+
+// New returns the Reader selected by cfg.Repo.Backend, defaulting to
+// "exec" (the git-CLI-backed implementation) when unset.
+func New(cfg config.Config) (Reader, error) {
+	name := cfg.Repo.Backend
+	if name == "" {
+		name = "exec"
+	}
+	f, ok := readers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported repo backend: %s", name)
+	}
+	return f(), nil
+}
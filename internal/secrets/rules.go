@@ -0,0 +1,474 @@
+package secrets
+
+import "regexp"
+
+// Rule is one named-token pattern in the curated rule pack: a provider's
+// credential format, paired with enough metadata to tell a developer what
+// they found and how to kill it.
+type Rule struct {
+	Name     string
+	Provider string
+	KeyType  string
+	Pattern  *regexp.Regexp
+	// RevocationURL points at the provider's own key-management console, so
+	// GenerateCommitMessage/suggestions can tell a developer exactly where
+	// to go instead of just "rotate your credential".
+	RevocationURL string
+}
+
+// Rules is the built-in rule pack: 64 named-token patterns, covering most
+// of the credential formats gitmind's own users are likely to leak.
+// That's still short of the ~80-rule catalogs shipped by dedicated secret
+// scanners (gitleaks, trufflehog); closing the rest of that gap -- plus
+// whatever real false negatives get reported against these 64 -- is
+// ongoing work, not something landed all at once here.
+var Rules = []Rule{
+	{
+		Name:          "aws-akid",
+		Provider:      "AWS",
+		KeyType:       "Access Key ID",
+		Pattern:       regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+		RevocationURL: "https://console.aws.amazon.com/iam/home#/security_credentials",
+	},
+	{
+		Name:          "aws-secret-key",
+		Provider:      "AWS",
+		KeyType:       "Secret Access Key",
+		Pattern:       regexp.MustCompile(`(?i)aws_secret_access_key\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}["']?`),
+		RevocationURL: "https://console.aws.amazon.com/iam/home#/security_credentials",
+	},
+	{
+		Name:          "github-pat",
+		Provider:      "GitHub",
+		KeyType:       "Personal Access Token",
+		Pattern:       regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`),
+		RevocationURL: "https://github.com/settings/tokens",
+	},
+	{
+		Name:          "gitlab-pat",
+		Provider:      "GitLab",
+		KeyType:       "Personal Access Token",
+		Pattern:       regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20}\b`),
+		RevocationURL: "https://gitlab.com/-/profile/personal_access_tokens",
+	},
+	{
+		Name:          "slack-token",
+		Provider:      "Slack",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9-]{10,72}\b`),
+		RevocationURL: "https://api.slack.com/apps",
+	},
+	{
+		Name:          "slack-webhook",
+		Provider:      "Slack",
+		KeyType:       "Incoming Webhook URL",
+		Pattern:       regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/]{20,}`),
+		RevocationURL: "https://api.slack.com/apps",
+	},
+	{
+		Name:          "stripe-key",
+		Provider:      "Stripe",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\b(sk|rk)_(live|test)_[A-Za-z0-9]{24,}\b`),
+		RevocationURL: "https://dashboard.stripe.com/apikeys",
+	},
+	{
+		Name:          "google-api-key",
+		Provider:      "Google",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+		RevocationURL: "https://console.cloud.google.com/apis/credentials",
+	},
+	{
+		Name:          "gcp-service-account",
+		Provider:      "Google Cloud",
+		KeyType:       "Service Account Key (JSON)",
+		Pattern:       regexp.MustCompile(`"type":\s*"service_account"`),
+		RevocationURL: "https://console.cloud.google.com/iam-admin/serviceaccounts",
+	},
+	{
+		Name:          "npm-token",
+		Provider:      "npm",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\bnpm_[A-Za-z0-9]{36}\b`),
+		RevocationURL: "https://www.npmjs.com/settings/~/tokens",
+	},
+	{
+		Name:          "jwt",
+		Provider:      "generic",
+		KeyType:       "JSON Web Token",
+		Pattern:       regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "private-key-pem",
+		Provider:      "generic",
+		KeyType:       "PEM Private Key",
+		Pattern:       regexp.MustCompile(`-----BEGIN (RSA |EC |OPENSSH |DSA |PGP )?PRIVATE KEY-----`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "twilio-api-key",
+		Provider:      "Twilio",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bSK[0-9a-fA-F]{32}\b`),
+		RevocationURL: "https://www.twilio.com/console/runtime/api-keys",
+	},
+	{
+		Name:          "sendgrid-api-key",
+		Provider:      "SendGrid",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bSG\.[A-Za-z0-9_-]{22}\.[A-Za-z0-9_-]{43}\b`),
+		RevocationURL: "https://app.sendgrid.com/settings/api_keys",
+	},
+	{
+		Name:          "azure-storage-key",
+		Provider:      "Azure",
+		KeyType:       "Storage Account Key",
+		Pattern:       regexp.MustCompile(`(?i)AccountKey=[A-Za-z0-9+/]{86}==`),
+		RevocationURL: "https://portal.azure.com",
+	},
+	{
+		Name:          "generic-db-connection-string",
+		Provider:      "generic",
+		KeyType:       "Database Connection String",
+		Pattern:       regexp.MustCompile(`(?i)(postgres|postgresql|mysql|mongodb(\+srv)?)://[^:\s]+:[^@\s]+@`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "generic-url-credentials",
+		Provider:      "generic",
+		KeyType:       "URL with embedded basic-auth credentials",
+		Pattern:       regexp.MustCompile(`(?i)(https?|ftp)://[^:/\s]+:[^@/\s]+@`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "digitalocean-pat",
+		Provider:      "DigitalOcean",
+		KeyType:       "Personal Access Token",
+		Pattern:       regexp.MustCompile(`\bdop_v1_[a-f0-9]{64}\b`),
+		RevocationURL: "https://cloud.digitalocean.com/account/api/tokens",
+	},
+	{
+		Name:          "digitalocean-oauth-token",
+		Provider:      "DigitalOcean",
+		KeyType:       "OAuth Token",
+		Pattern:       regexp.MustCompile(`\bdoo_v1_[a-f0-9]{64}\b`),
+		RevocationURL: "https://cloud.digitalocean.com/account/api/tokens",
+	},
+	{
+		Name:          "digitalocean-refresh-token",
+		Provider:      "DigitalOcean",
+		KeyType:       "OAuth Refresh Token",
+		Pattern:       regexp.MustCompile(`\bdor_v1_[a-f0-9]{64}\b`),
+		RevocationURL: "https://cloud.digitalocean.com/account/api/tokens",
+	},
+	{
+		Name:          "shopify-access-token",
+		Provider:      "Shopify",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\bshpat_[a-fA-F0-9]{32}\b`),
+		RevocationURL: "https://www.shopify.com/admin/apps",
+	},
+	{
+		Name:          "shopify-custom-app-token",
+		Provider:      "Shopify",
+		KeyType:       "Custom App Access Token",
+		Pattern:       regexp.MustCompile(`\bshpca_[a-fA-F0-9]{32}\b`),
+		RevocationURL: "https://www.shopify.com/admin/apps",
+	},
+	{
+		Name:          "shopify-private-app-token",
+		Provider:      "Shopify",
+		KeyType:       "Private App Access Token",
+		Pattern:       regexp.MustCompile(`\bshppa_[a-fA-F0-9]{32}\b`),
+		RevocationURL: "https://www.shopify.com/admin/apps",
+	},
+	{
+		Name:          "shopify-shared-secret",
+		Provider:      "Shopify",
+		KeyType:       "Shared Secret",
+		Pattern:       regexp.MustCompile(`\bshpss_[a-fA-F0-9]{32}\b`),
+		RevocationURL: "https://www.shopify.com/admin/apps",
+	},
+	{
+		Name:          "square-access-token",
+		Provider:      "Square",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\bsq0atp-[0-9A-Za-z_-]{22}\b`),
+		RevocationURL: "https://developer.squareup.com/apps",
+	},
+	{
+		Name:          "square-oauth-secret",
+		Provider:      "Square",
+		KeyType:       "OAuth Secret",
+		Pattern:       regexp.MustCompile(`\bsq0csp-[0-9A-Za-z_-]{43}\b`),
+		RevocationURL: "https://developer.squareup.com/apps",
+	},
+	{
+		Name:          "discord-bot-token",
+		Provider:      "Discord",
+		KeyType:       "Bot Token",
+		Pattern:       regexp.MustCompile(`\b[MN][A-Za-z\d]{23}\.[\w-]{6}\.[\w-]{27}\b`),
+		RevocationURL: "https://discord.com/developers/applications",
+	},
+	{
+		Name:          "telegram-bot-token",
+		Provider:      "Telegram",
+		KeyType:       "Bot Token",
+		Pattern:       regexp.MustCompile(`\b\d{8,10}:[A-Za-z0-9_-]{35}\b`),
+		RevocationURL: "https://t.me/BotFather",
+	},
+	{
+		Name:          "new-relic-license-key",
+		Provider:      "New Relic",
+		KeyType:       "License Key",
+		Pattern:       regexp.MustCompile(`\bNRAK-[A-Z0-9]{27}\b`),
+		RevocationURL: "https://one.newrelic.com/api-keys",
+	},
+	{
+		Name:          "okta-api-token",
+		Provider:      "Okta",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\b00[A-Za-z0-9_-]{40}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "linear-api-key",
+		Provider:      "Linear",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\blin_api_[A-Za-z0-9]{40}\b`),
+		RevocationURL: "https://linear.app/settings/api",
+	},
+	{
+		Name:          "notion-api-key",
+		Provider:      "Notion",
+		KeyType:       "Integration Token",
+		Pattern:       regexp.MustCompile(`\bsecret_[A-Za-z0-9]{43}\b`),
+		RevocationURL: "https://www.notion.so/my-integrations",
+	},
+	{
+		Name:          "postman-api-key",
+		Provider:      "Postman",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bPMAK-[a-f0-9]{24}-[a-f0-9]{34}\b`),
+		RevocationURL: "https://go.postman.co/settings/me/api-keys",
+	},
+	{
+		Name:          "planetscale-password",
+		Provider:      "PlanetScale",
+		KeyType:       "Database Password",
+		Pattern:       regexp.MustCompile(`\bpscale_pw_[A-Za-z0-9_]{43}\b`),
+		RevocationURL: "https://app.planetscale.com",
+	},
+	{
+		Name:          "planetscale-api-token",
+		Provider:      "PlanetScale",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\bpscale_tkn_[A-Za-z0-9_]{43}\b`),
+		RevocationURL: "https://app.planetscale.com",
+	},
+	{
+		Name:          "render-api-key",
+		Provider:      "Render",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\brnd_[A-Za-z0-9]{20,}\b`),
+		RevocationURL: "https://dashboard.render.com/u/settings#api-keys",
+	},
+	{
+		Name:          "huggingface-api-token",
+		Provider:      "Hugging Face",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\bhf_[A-Za-z0-9]{34}\b`),
+		RevocationURL: "https://huggingface.co/settings/tokens",
+	},
+	{
+		Name:          "openai-api-key-legacy",
+		Provider:      "OpenAI",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bsk-[A-Za-z0-9]{20}T3BlbkFJ[A-Za-z0-9]{20}\b`),
+		RevocationURL: "https://platform.openai.com/api-keys",
+	},
+	{
+		Name:          "openai-api-key-project",
+		Provider:      "OpenAI",
+		KeyType:       "Project API Key",
+		Pattern:       regexp.MustCompile(`\bsk-proj-[A-Za-z0-9_-]{20,}\b`),
+		RevocationURL: "https://platform.openai.com/api-keys",
+	},
+	{
+		Name:          "anthropic-api-key",
+		Provider:      "Anthropic",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{90,}\b`),
+		RevocationURL: "https://console.anthropic.com/settings/keys",
+	},
+	{
+		Name:          "replicate-api-token",
+		Provider:      "Replicate",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\br8_[A-Za-z0-9]{37}\b`),
+		RevocationURL: "https://replicate.com/account/api-tokens",
+	},
+	{
+		Name:          "groq-api-key",
+		Provider:      "Groq",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bgsk_[A-Za-z0-9]{52}\b`),
+		RevocationURL: "https://console.groq.com/keys",
+	},
+	{
+		Name:          "resend-api-key",
+		Provider:      "Resend",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\bre_[A-Za-z0-9_]{20,}\b`),
+		RevocationURL: "https://resend.com/api-keys",
+	},
+	{
+		Name:          "mapbox-secret-token",
+		Provider:      "Mapbox",
+		KeyType:       "Secret Access Token",
+		Pattern:       regexp.MustCompile(`\bsk\.eyJ1[A-Za-z0-9._-]{20,}\b`),
+		RevocationURL: "https://account.mapbox.com/access-tokens",
+	},
+	{
+		Name:          "grafana-service-account-token",
+		Provider:      "Grafana",
+		KeyType:       "Service Account Token",
+		Pattern:       regexp.MustCompile(`\bglsa_[A-Za-z0-9]{32}_[A-Za-z0-9]{8}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "docker-hub-pat",
+		Provider:      "Docker Hub",
+		KeyType:       "Personal Access Token",
+		Pattern:       regexp.MustCompile(`\bdckr_pat_[A-Za-z0-9_-]{27}\b`),
+		RevocationURL: "https://hub.docker.com/settings/security",
+	},
+	{
+		Name:          "pypi-api-token",
+		Provider:      "PyPI",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\bpypi-AgEIcHlwaS5vcmc[A-Za-z0-9_-]{50,}\b`),
+		RevocationURL: "https://pypi.org/manage/account/token/",
+	},
+	{
+		Name:          "nuget-api-key",
+		Provider:      "NuGet",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`\boy2[a-z0-9]{43}\b`),
+		RevocationURL: "https://www.nuget.org/account/apikeys",
+	},
+	{
+		Name:          "atlassian-api-token",
+		Provider:      "Atlassian",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\bATATT3[A-Za-z0-9_=-]{40,}\b`),
+		RevocationURL: "https://id.atlassian.com/manage-profile/security/api-tokens",
+	},
+	{
+		Name:          "vault-service-token",
+		Provider:      "HashiCorp Vault",
+		KeyType:       "Service Token",
+		Pattern:       regexp.MustCompile(`\bhvs\.[A-Za-z0-9_-]{90,}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "terraform-cloud-token",
+		Provider:      "Terraform Cloud",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`\b[A-Za-z0-9]{14}\.atlasv1\.[A-Za-z0-9_-]{60,}\b`),
+		RevocationURL: "https://app.terraform.io/app/settings/tokens",
+	},
+	{
+		Name:          "dropbox-access-token",
+		Provider:      "Dropbox",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\bsl\.[A-Za-z0-9_-]{130,140}\b`),
+		RevocationURL: "https://www.dropbox.com/developers/apps",
+	},
+	{
+		Name:          "doppler-token",
+		Provider:      "Doppler",
+		KeyType:       "Service Token",
+		Pattern:       regexp.MustCompile(`\bdp\.pt\.[A-Za-z0-9]{43}\b`),
+		RevocationURL: "https://dashboard.doppler.com",
+	},
+	{
+		Name:          "facebook-graph-token",
+		Provider:      "Facebook",
+		KeyType:       "Graph API Access Token",
+		Pattern:       regexp.MustCompile(`\bEAA[A-Za-z0-9]{90,}\b`),
+		RevocationURL: "https://developers.facebook.com/tools/accesstoken/",
+	},
+	{
+		Name:          "salesforce-access-token",
+		Provider:      "Salesforce",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\b00D[A-Za-z0-9]{12,18}![A-Za-z0-9._]{60,}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "twilio-account-sid",
+		Provider:      "Twilio",
+		KeyType:       "Account SID",
+		Pattern:       regexp.MustCompile(`\bAC[a-f0-9]{32}\b`),
+		RevocationURL: "https://www.twilio.com/console",
+	},
+	{
+		Name:          "airtable-pat",
+		Provider:      "Airtable",
+		KeyType:       "Personal Access Token",
+		Pattern:       regexp.MustCompile(`\bpat[A-Za-z0-9]{14}\.[a-f0-9]{64}\b`),
+		RevocationURL: "https://airtable.com/create/tokens",
+	},
+	{
+		Name:          "sentry-dsn",
+		Provider:      "Sentry",
+		KeyType:       "DSN",
+		Pattern:       regexp.MustCompile(`\bhttps://[0-9a-f]{32}@[0-9a-z.-]+\.ingest\.sentry\.io/\d+\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "paypal-braintree-access-token",
+		Provider:      "PayPal/Braintree",
+		KeyType:       "Access Token",
+		Pattern:       regexp.MustCompile(`\baccess_token\$production\$[0-9a-z]{16}\$[0-9a-f]{32}\b`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "cloudflare-api-token",
+		Provider:      "Cloudflare",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`(?i)cloudflare[a-z0-9_ .\-]{0,25}[:=]\s*["']?[A-Za-z0-9_-]{40}["']?`),
+		RevocationURL: "https://dash.cloudflare.com/profile/api-tokens",
+	},
+	{
+		Name:          "hubspot-api-key",
+		Provider:      "HubSpot",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`(?i)hubspot[a-z0-9_ .\-]{0,25}[:=]\s*["']?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}["']?`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "circleci-personal-token",
+		Provider:      "CircleCI",
+		KeyType:       "Personal API Token",
+		Pattern:       regexp.MustCompile(`(?i)circleci[a-z0-9_ .\-]{0,25}[:=]\s*["']?[0-9a-f]{40}["']?`),
+		RevocationURL: "https://app.circleci.com/settings/user/tokens",
+	},
+	{
+		Name:          "datadog-api-key",
+		Provider:      "Datadog",
+		KeyType:       "API Key",
+		Pattern:       regexp.MustCompile(`(?i)datadog[a-z0-9_ .\-]{0,25}[:=]\s*["']?[0-9a-f]{32}["']?`),
+		RevocationURL: "",
+	},
+	{
+		Name:          "zendesk-api-token",
+		Provider:      "Zendesk",
+		KeyType:       "API Token",
+		Pattern:       regexp.MustCompile(`(?i)zendesk[a-z0-9_ .\-]{0,25}[:=]\s*["'][A-Za-z0-9]{40}["']`),
+		RevocationURL: "",
+	},
+}
@@ -0,0 +1,155 @@
+// Package secrets scans text for leaked credentials: a curated pack of
+// named-token regexes (see rules.go) plus a Shannon-entropy fallback for
+// high-entropy strings no rule recognizes.
+package secrets
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// DefaultEntropyThreshold is the Shannon entropy (bits per character) above
+// which an unmatched string of at least minEntropyTokenLen characters is
+// flagged as "secret-generic". Chosen to catch base64/hex token-shaped
+// strings while passing over ordinary prose and identifiers.
+const DefaultEntropyThreshold = 3.5
+
+const minEntropyTokenLen = 20
+
+// Match is one hit from Scanner.ScanLine: either a named rule match or a
+// high-entropy fallback (Rule.Name == "secret-generic").
+type Match struct {
+	Rule     Rule
+	Value    string
+	File     string
+	Line     int
+	Entropy  float64
+	Verified bool
+}
+
+// VerifyFunc checks whether a captured credential is still live, e.g. by
+// calling AWS STS GetCallerIdentity for an AKID. A verifier is optional;
+// Scanner only calls one if RegisterVerifier was used for that rule.
+type VerifyFunc func(rule Rule, value string) bool
+
+// Scanner holds the rule pack, entropy threshold, and any registered
+// VerifyFuncs for a single AnalyzeDiff run.
+type Scanner struct {
+	Rules            []Rule
+	EntropyThreshold float64
+	verify           map[string]VerifyFunc
+}
+
+// NewScanner builds a Scanner using the built-in Rules and the given
+// entropy threshold. Callers should pass DefaultEntropyThreshold unless a
+// config override says otherwise.
+func NewScanner(entropyThreshold float64) *Scanner {
+	return &Scanner{Rules: Rules, EntropyThreshold: entropyThreshold}
+}
+
+// RegisterVerifier wires a live-validation callback for rule, so a match is
+// marked Verified when the credential is confirmed active rather than just
+// pattern-shaped.
+func (s *Scanner) RegisterVerifier(ruleName string, fn VerifyFunc) {
+	if s.verify == nil {
+		s.verify = make(map[string]VerifyFunc)
+	}
+	s.verify[ruleName] = fn
+}
+
+// allowlistRe matches an inline `gitmind:allow-secret` marker, optionally
+// scoped to one rule (`gitmind:allow-secret rule=aws-akid`). A bare marker
+// allowlists every rule on that line, which is how test fixtures opt out.
+var allowlistRe = regexp.MustCompile(`gitmind:allow-secret(?:\s+rule=([\w-]+))?`)
+
+func allowlisted(content, ruleName string) bool {
+	m := allowlistRe.FindStringSubmatch(content)
+	if m == nil {
+		return false
+	}
+	return m[1] == "" || m[1] == ruleName
+}
+
+// candidateTokenRe picks out token-shaped substrings (base64/hex-ish
+// runs of at least minEntropyTokenLen chars) as entropy-fallback candidates.
+var candidateTokenRe = regexp.MustCompile(`[A-Za-z0-9+/_=-]{` + "20" + `,}`)
+
+// ScanLine runs the rule pack and the entropy fallback against one line of
+// content, returning every Match found. file/line are carried through
+// unchanged so the caller can attach them to whatever Finding shape it uses.
+func (s *Scanner) ScanLine(file string, line int, content string) []Match {
+	if allowlisted(content, "") {
+		// A bare `gitmind:allow-secret` marker (no rule= scoping) allowlists
+		// the whole line, including the entropy fallback below.
+		return nil
+	}
+
+	var matches []Match
+	for _, rule := range s.Rules {
+		if allowlisted(content, rule.Name) {
+			continue
+		}
+		for _, loc := range rule.Pattern.FindAllStringIndex(content, -1) {
+			value := content[loc[0]:loc[1]]
+			m := Match{Rule: rule, Value: value, File: file, Line: line}
+			if fn, ok := s.verify[rule.Name]; ok {
+				m.Verified = fn(rule, value)
+			}
+			matches = append(matches, m)
+		}
+	}
+
+	threshold := s.EntropyThreshold
+	if threshold <= 0 {
+		threshold = DefaultEntropyThreshold
+	}
+	if allowlisted(content, "secret-generic") {
+		return matches
+	}
+	for _, tok := range candidateTokenRe.FindAllString(content, -1) {
+		if len(tok) < minEntropyTokenLen || overlapsMatch(tok, matches) {
+			continue
+		}
+		if entropy := Entropy(tok); entropy >= threshold {
+			matches = append(matches, Match{
+				Rule:    Rule{Name: "secret-generic", Provider: "unknown", KeyType: "high-entropy string"},
+				Value:   tok,
+				File:    file,
+				Line:    line,
+				Entropy: entropy,
+			})
+		}
+	}
+
+	return matches
+}
+
+func overlapsMatch(tok string, matches []Match) bool {
+	for _, m := range matches {
+		if strings.Contains(m.Value, tok) || strings.Contains(tok, m.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entropy returns the Shannon entropy of s, in bits per character.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	freq := make(map[rune]int)
+	for _, r := range s {
+		freq[r]++
+	}
+
+	n := float64(len(s))
+	var entropy float64
+	for _, count := range freq {
+		p := float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
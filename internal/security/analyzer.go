@@ -0,0 +1,83 @@
+package security
+
+import (
+	"context"
+	"strings"
+)
+
+// Analyzer is implemented by every security scanner SecurityAnalyzer knows
+// how to run, whether it's compiled in (gosec, bandit, ...) or declared in
+// config as an external plugin. runAnalyzer dispatches through a registry
+// of Analyzers instead of a hard-coded switch, so adding a new scanner
+// doesn't require a gitmind code change for the external case.
+type Analyzer interface {
+	Name() string
+	Supports(file string) bool
+	Run(ctx context.Context, files []string) ([]Finding, error)
+}
+
+// builtinFactories holds a constructor per compiled-in analyzer, keyed by
+// the name used in config.Security.Analyzers. It's a factory rather than a
+// ready-made Analyzer because each one closes over the SecurityAnalyzer
+// whose runX method it delegates to.
+var builtinFactories = map[string]func(*SecurityAnalyzer) Analyzer{}
+
+func registerBuiltin(name string, exts []string, run func(sa *SecurityAnalyzer, files []string) ([]Finding, error)) {
+	builtinFactories[name] = func(sa *SecurityAnalyzer) Analyzer {
+		return builtinAnalyzer{name: name, exts: exts, sa: sa, run: run}
+	}
+}
+
+// builtinAnalyzer adapts one of SecurityAnalyzer's existing runX methods to
+// the Analyzer interface.
+type builtinAnalyzer struct {
+	name string
+	exts []string
+	sa   *SecurityAnalyzer
+	run  func(sa *SecurityAnalyzer, files []string) ([]Finding, error)
+}
+
+func (b builtinAnalyzer) Name() string { return b.name }
+
+// Supports reports whether file looks relevant to this analyzer. A nil exts
+// means the analyzer decides relevance itself (semgrep, securecodewarrior).
+func (b builtinAnalyzer) Supports(file string) bool {
+	if len(b.exts) == 0 {
+		return true
+	}
+	for _, ext := range b.exts {
+		if strings.HasSuffix(file, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run delegates to the wrapped runX method, which still does its own
+// extension filtering -- Supports exists for callers that want to query
+// relevance without running the analyzer (e.g. a future plugin dispatcher).
+func (b builtinAnalyzer) Run(_ context.Context, files []string) ([]Finding, error) {
+	return b.run(b.sa, files)
+}
+
+func init() {
+	registerBuiltin("gosec", []string{".go"}, (*SecurityAnalyzer).runGosec)
+	registerBuiltin("bandit", []string{".py"}, (*SecurityAnalyzer).runBandit)
+	registerBuiltin("eslint-security", []string{".js", ".ts", ".jsx", ".tsx"}, (*SecurityAnalyzer).runESLintSecurity)
+	registerBuiltin("semgrep", nil, (*SecurityAnalyzer).runSemgrep)
+	registerBuiltin("safety", []string{".py", "requirements.txt", "Pipfile", "pyproject.toml"}, (*SecurityAnalyzer).runSafety)
+	registerBuiltin("brakeman", []string{".rb", ".erb", "Gemfile"}, (*SecurityAnalyzer).runBrakeman)
+	registerBuiltin("spotbugs", []string{".java", ".class", ".jar"}, (*SecurityAnalyzer).runSpotBugs)
+	registerBuiltin("psalm", []string{".php"}, (*SecurityAnalyzer).runPsalm)
+	registerBuiltin("phpstan", []string{".php"}, (*SecurityAnalyzer).runPHPStan)
+	registerBuiltin("cppcheck", []string{".c", ".cpp", ".cxx", ".cc", ".h", ".hpp"}, (*SecurityAnalyzer).runCppCheck)
+	registerBuiltin("flawfinder", []string{".c", ".cpp", ".cxx", ".cc", ".h", ".hpp"}, (*SecurityAnalyzer).runFlawfinder)
+	registerBuiltin("cargo-audit", []string{".rs", "Cargo.toml", "Cargo.lock"}, (*SecurityAnalyzer).runCargoAudit)
+	registerBuiltin("clippy", []string{".rs"}, (*SecurityAnalyzer).runClippy)
+	registerBuiltin("securecodewarrior", nil, (*SecurityAnalyzer).runSecureCodeWarrior)
+	registerBuiltin("config-audit", nil, (*SecurityAnalyzer).runConfigAnalyzer)
+	registerBuiltin("staticcheck", []string{".go"}, (*SecurityAnalyzer).runStaticcheck)
+	registerBuiltin("govet", []string{".go"}, (*SecurityAnalyzer).runGovet)
+	registerBuiltin("golangci-lint", []string{".go"}, (*SecurityAnalyzer).runGolangciLint)
+	registerBuiltin("forbidden-imports", []string{".go"}, (*SecurityAnalyzer).runForbiddenImports)
+}
@@ -0,0 +1,195 @@
+package security
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gitdiff "github.com/Harri200191/gitmind/internal/diff"
+)
+
+// lineRange is an inclusive [Start, End] span of added/changed lines in a
+// single file, derived from a diff hunk's post-image side.
+type lineRange struct {
+	Start int
+	End   int
+}
+
+func (r lineRange) contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+// changedLineRanges derives, per file, the line ranges the diff actually
+// touches, so filterBaseline can tell a pre-existing, untouched finding
+// from a new one.
+func changedLineRanges(diff string) map[string][]lineRange {
+	parsed, err := gitdiff.Parse(diff)
+	if err != nil {
+		return nil
+	}
+
+	ranges := make(map[string][]lineRange)
+	for _, fd := range parsed {
+		if fd.IsBinary {
+			continue
+		}
+		path := fd.Path()
+		for _, h := range fd.Hunks {
+			if h.NewLines == 0 {
+				continue
+			}
+			ranges[path] = append(ranges[path], lineRange{Start: h.NewStart, End: h.NewStart + h.NewLines - 1})
+		}
+	}
+	return ranges
+}
+
+func touchesChangedLines(ranges []lineRange, line int) bool {
+	for _, r := range ranges {
+		if r.contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// baselineFilteredTypePrefixes are the whole-file scanners that report a
+// finding regardless of whether the diff touched that line, so they're the
+// ones baseline/line-range suppression applies to. Dependency-scanner and
+// pattern-based findings are already scoped to the diff itself.
+var baselineFilteredTypePrefixes = []string{"gosec-", "semgrep-", "cppcheck-", "spotbugs-"}
+
+func isBaselineFiltered(f Finding) bool {
+	for _, prefix := range baselineFilteredTypePrefixes {
+		if strings.HasPrefix(f.Type, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BaselineEntry is one suppressed finding, identified by file, rule, and a
+// hash of its line range so the baseline survives unrelated line-number
+// churn elsewhere in the file.
+type BaselineEntry struct {
+	File          string `json:"file"`
+	Rule          string `json:"rule"`
+	LineRangeHash string `json:"line_range_hash"`
+}
+
+// lineRangeHash buckets line into groups of 3 before hashing, so a finding
+// that drifts by a line or two because of unrelated edits elsewhere in the
+// file still matches its baseline entry.
+func lineRangeHash(file, rule string, line int) string {
+	bucket := line / 3
+	h := fnv.New32a()
+	h.Write([]byte(file + "|" + rule + "|" + strconv.Itoa(bucket)))
+	return strconv.FormatUint(uint64(h.Sum32()), 16)
+}
+
+// DefaultBaselinePath is used when config.Security.BaselinePath is empty.
+const DefaultBaselinePath = ".gitmind-security-baseline.json"
+
+// LoadBaseline reads a `.gitmind-security-baseline.json`-shaped snapshot
+// from path, so subsequent AnalyzeDiff calls suppress any whole-file-scanner
+// finding already present in it. A missing file is not an error -- most
+// repos adopting gitmind won't have one yet.
+func (sa *SecurityAnalyzer) LoadBaseline(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var entries []BaselineEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	sa.baseline = make(map[string]bool, len(entries))
+	for _, e := range entries {
+		sa.baseline[e.File+"|"+e.Rule+"|"+e.LineRangeHash] = true
+	}
+	return nil
+}
+
+// SaveBaseline snapshots findings (normally an AnalyzeDiff report's
+// Findings) to path as BaselineEntry JSON, for `gitmind security baseline
+// update` to call after a team has triaged a legacy repo's existing issues.
+func (sa *SecurityAnalyzer) SaveBaseline(path string, findings []Finding) error {
+	entries := make([]BaselineEntry, 0, len(findings))
+	for _, f := range findings {
+		if !isBaselineFiltered(f) {
+			continue
+		}
+		entries = append(entries, BaselineEntry{
+			File:          f.File,
+			Rule:          f.Rule,
+			LineRangeHash: lineRangeHash(f.File, f.Rule, f.Line),
+		})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (sa *SecurityAnalyzer) inBaseline(f Finding) bool {
+	if len(sa.baseline) == 0 {
+		return false
+	}
+	key := f.File + "|" + f.Rule + "|" + lineRangeHash(f.File, f.Rule, f.Line)
+	return sa.baseline[key]
+}
+
+// suppressed checks config.Security.Suppress for a matching, unexpired
+// entry, independent of which analyzer produced the finding.
+func (sa *SecurityAnalyzer) suppressed(f Finding) bool {
+	for _, rule := range sa.config.Security.Suppress {
+		if rule.File != "" && rule.File != f.File {
+			continue
+		}
+		if rule.Rule != "" && rule.Rule != f.Rule {
+			continue
+		}
+		if rule.Expiry != "" {
+			if expiry, err := time.Parse("2006-01-02", rule.Expiry); err == nil && time.Now().After(expiry) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// filterBaseline drops whole-file-scanner findings that are either
+// explicitly suppressed (config.Security.Suppress), present in the loaded
+// baseline, or outside the diff's changed-line ranges -- i.e. pre-existing
+// issues this change didn't touch.
+func (sa *SecurityAnalyzer) filterBaseline(findings []Finding, diff string) []Finding {
+	ranges := changedLineRanges(diff)
+
+	filtered := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if sa.suppressed(f) {
+			continue
+		}
+		if isBaselineFiltered(f) {
+			if sa.inBaseline(f) {
+				continue
+			}
+			if !touchesChangedLines(ranges[f.File], f.Line) {
+				continue
+			}
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
@@ -0,0 +1,224 @@
+package security
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigAnalyzer inspects framework and CI configuration rather than source
+// code: missing Content-Security-Policy/CSRF/cookie-flag settings in
+// Rails/Phoenix/Express security-header middleware, and overly broad GitHub
+// Actions `permissions:` blocks. It's registered as the "config-audit"
+// builtin analyzer and, unlike the language-specific scanners, applies to
+// any project regardless of its primary language.
+type ConfigAnalyzer struct {
+	sa *SecurityAnalyzer
+}
+
+// runConfigAnalyzer adapts ConfigAnalyzer to the run signature
+// registerBuiltin expects.
+func (sa *SecurityAnalyzer) runConfigAnalyzer(files []string) ([]Finding, error) {
+	c := ConfigAnalyzer{sa: sa}
+	return c.Analyze(files), nil
+}
+
+// Analyze checks every file whose path or content marks it as framework or
+// CI configuration, skipping anything else. A file that fails to read
+// simply yields no findings, the same way the pattern engine treats an
+// unreadable file.
+func (c *ConfigAnalyzer) Analyze(files []string) []Finding {
+	var findings []Finding
+	for _, f := range files {
+		if isGitHubWorkflowFile(f) {
+			findings = append(findings, c.checkWorkflowPermissions(f)...)
+			continue
+		}
+		findings = append(findings, c.checkSecurityHeaders(f)...)
+	}
+	return findings
+}
+
+// isGitHubWorkflowFile reports whether f is a GitHub Actions workflow
+// definition.
+func isGitHubWorkflowFile(f string) bool {
+	if !strings.Contains(filepath.ToSlash(f), ".github/workflows/") {
+		return false
+	}
+	return strings.HasSuffix(f, ".yml") || strings.HasSuffix(f, ".yaml")
+}
+
+// checkWorkflowPermissions parses path with yaml.v3 into a Node tree (rather
+// than a plain struct) specifically to keep each offending key's source
+// line, and flags a top-level or per-job `permissions: write-all`.
+func (c *ConfigAnalyzer) checkWorkflowPermissions(path string) []Finding {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+
+	var findings []Finding
+	if perm := mappingValue(root, "permissions"); perm != nil {
+		if f := writeAllFinding(path, perm, "top-level"); f != nil {
+			findings = append(findings, *f)
+		}
+	}
+
+	if jobs := mappingValue(root, "jobs"); jobs != nil && jobs.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(jobs.Content); i += 2 {
+			jobName := jobs.Content[i].Value
+			if perm := mappingValue(jobs.Content[i+1], "permissions"); perm != nil {
+				if f := writeAllFinding(path, perm, fmt.Sprintf("job %q", jobName)); f != nil {
+					findings = append(findings, *f)
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// mappingValue returns the value node for key in node, or nil if node isn't
+// a mapping or doesn't have key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// writeAllFinding reports permNode as a finding when it's the "write-all"
+// shorthand, the broadest grant GitHub Actions permissions support.
+func writeAllFinding(path string, permNode *yaml.Node, scope string) *Finding {
+	if permNode.Kind != yaml.ScalarNode || permNode.Value != "write-all" {
+		return nil
+	}
+	return &Finding{
+		Severity:   "high",
+		Type:       "config-workflow-write-all",
+		File:       path,
+		Line:       permNode.Line,
+		Message:    fmt.Sprintf("%s permissions grants write-all, far broader than most workflows need", scope),
+		Rule:       "config-workflow-permissions",
+		Suggestion: "Scope permissions: down to only what the workflow needs (e.g. contents: read), per job if a single job needs more than the rest",
+	}
+}
+
+// securityHeaderFramework recognizes, from a file's content, that it sets
+// up a framework's security-header/session middleware (setup), and what a
+// CSP directive, CSRF protection, and secure/HttpOnly/SameSite cookie flags
+// look like once it has.
+type securityHeaderFramework struct {
+	name         string
+	setup        *regexp.Regexp
+	csp          *regexp.Regexp
+	csrf         *regexp.Regexp
+	secureCookie *regexp.Regexp
+	httpOnly     *regexp.Regexp
+	sameSite     *regexp.Regexp
+}
+
+var securityHeaderFrameworks = []securityHeaderFramework{
+	{
+		name:         "express",
+		setup:        regexp.MustCompile(`app\.use\(\s*helmet\(`),
+		csp:          regexp.MustCompile(`contentSecurityPolicy`),
+		csrf:         regexp.MustCompile(`csurf|csrf`),
+		secureCookie: regexp.MustCompile(`(?i)secure:\s*true`),
+		httpOnly:     regexp.MustCompile(`(?i)httpOnly:\s*true`),
+		sameSite:     regexp.MustCompile(`(?i)sameSite:\s*['"]?(strict|lax)`),
+	},
+	{
+		name:         "rails",
+		setup:        regexp.MustCompile(`SecureHeaders::Configuration\.default|class ApplicationController`),
+		csp:          regexp.MustCompile(`content_security_policy|:csp\s*=>`),
+		csrf:         regexp.MustCompile(`protect_from_forgery`),
+		secureCookie: regexp.MustCompile(`(?i)secure:\s*true`),
+		httpOnly:     regexp.MustCompile(`(?i)httponly:\s*true`),
+		sameSite:     regexp.MustCompile(`(?i)same_site:\s*:(strict|lax)`),
+	},
+	{
+		name:         "phoenix",
+		setup:        regexp.MustCompile(`use\s+\w+Web\.Endpoint|plug\s+:put_secure_browser_headers`),
+		csp:          regexp.MustCompile(`content-security-policy`),
+		csrf:         regexp.MustCompile(`plug\s+:protect_from_forgery|protect_from_forgery`),
+		secureCookie: regexp.MustCompile(`secure:\s*true`),
+		httpOnly:     regexp.MustCompile(`http_only:\s*true`),
+		sameSite:     regexp.MustCompile(`same_site:\s*"(Strict|Lax)"`),
+	},
+}
+
+// checkSecurityHeaders flags a framework's security-header/session
+// middleware setup (detected via securityHeaderFrameworks' setup regex) that
+// is missing a CSP directive, CSRF protection, or secure/HttpOnly/SameSite
+// cookie flags. A file that doesn't match any framework's setup regex, or
+// that can't be read, yields no findings.
+func (c *ConfigAnalyzer) checkSecurityHeaders(path string) []Finding {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	text := string(content)
+	lines := strings.Split(text, "\n")
+
+	var findings []Finding
+	for _, fw := range securityHeaderFrameworks {
+		loc := fw.setup.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		line := lineOf(text, loc[0])
+
+		if !fw.csp.MatchString(text) {
+			findings = append(findings, missingHeaderFinding(path, line, fw.name, "config-missing-csp",
+				"no Content-Security-Policy directive found alongside the "+fw.name+" security-header setup",
+				lines))
+		}
+		if !fw.csrf.MatchString(text) {
+			findings = append(findings, missingHeaderFinding(path, line, fw.name, "config-missing-csrf",
+				"no CSRF protection found alongside the "+fw.name+" security-header setup",
+				lines))
+		}
+		if !fw.secureCookie.MatchString(text) || !fw.httpOnly.MatchString(text) || !fw.sameSite.MatchString(text) {
+			findings = append(findings, missingHeaderFinding(path, line, fw.name, "config-insecure-cookie-flags",
+				"session cookies are missing one of Secure/HttpOnly/SameSite alongside the "+fw.name+" security-header setup",
+				lines))
+		}
+	}
+	return findings
+}
+
+// missingHeaderFinding builds the Finding a checkSecurityHeaders gap
+// produces, pointing Line at the middleware setup line since there's no
+// "offending key" to point at for something that's absent.
+func missingHeaderFinding(path string, line int, framework, findingType, message string, lines []string) Finding {
+	return Finding{
+		Severity:   "medium",
+		Type:       findingType,
+		File:       path,
+		Line:       line,
+		Message:    message,
+		Rule:       findingType,
+		Suggestion: "Configure " + strings.TrimPrefix(findingType, "config-missing-") + " explicitly rather than relying on framework defaults",
+	}
+}
+
+// lineOf returns the 1-indexed line number byteOffset falls on within text.
+func lineOf(text string, byteOffset int) int {
+	return 1 + strings.Count(text[:byteOffset], "\n")
+}
@@ -0,0 +1,73 @@
+package security
+
+import "regexp"
+
+// Evidence captures reproduction detail beyond "file:line" for a Finding:
+// the actual source line(s) involved and, for taint-style findings, the
+// tainted input and the nearest enclosing function, so a report reader
+// doesn't have to reopen the file and re-derive what was actually seen.
+// HTTPRequest/HTTPResponse are for findings that come with a literal
+// request/response pair (a dynamic scanner, say); Source/Supporting are
+// for everything else.
+type Evidence struct {
+	// Summary is a short free-form description of what was found, e.g.
+	// "tainted input reaches a raw SQL query".
+	Summary string `json:"summary,omitempty"`
+	// Source is the line (or tool-provided excerpt) the finding is about:
+	// brakeman's user_input, psalm's selected_text, semgrep's matched
+	// lines, or the pattern engine's matched source line.
+	Source string `json:"source,omitempty"`
+	// Function is the nearest enclosing function/method name, when one
+	// could be determined. Best-effort -- see enclosingFunctionName.
+	Function string `json:"function,omitempty"`
+	// Supporting holds additional snippets that help explain Source: a
+	// taint trace's intermediate steps, a dataflow source/sink pair, etc.
+	Supporting   []string      `json:"supporting,omitempty"`
+	HTTPRequest  *HTTPExchange `json:"http_request,omitempty"`
+	HTTPResponse *HTTPExchange `json:"http_response,omitempty"`
+}
+
+// HTTPExchange is one side of a request/response pair attached to a
+// Finding's Evidence, for dynamic or web-focused analyzers that captured
+// the actual traffic that triggered the finding.
+type HTTPExchange struct {
+	Method  string            `json:"method,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// funcNameRe matches a function/method definition line per language, for
+// enclosingFunctionName's backward scan. This is a textual heuristic, not
+// a real parse -- a line that merely looks like a definition (e.g. inside
+// a string or comment) can produce a false match. A genuine AST walk would
+// need go/parser, Python's ast module, or a JS parser, none of which are
+// vendored here (see the similar tradeoff noted in reachability.go).
+var funcNameRe = map[string]*regexp.Regexp{
+	"go":         regexp.MustCompile(`^\s*func\s+(?:\([^)]*\)\s*)?([A-Za-z_][A-Za-z0-9_]*)\s*\(`),
+	"python":     regexp.MustCompile(`^\s*def\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`),
+	"javascript": regexp.MustCompile(`^\s*(?:async\s+)?function\s*([A-Za-z_][A-Za-z0-9_]*)?\s*\(|^\s*(?:const|let|var)\s+([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(?:async\s*)?\(`),
+}
+
+// enclosingFunctionName scans lines backward from lineNo (1-indexed) for
+// the nearest line that looks like a function/method definition in lang,
+// returning its name, or "" if lang isn't covered or nothing was found.
+func enclosingFunctionName(lines []string, lineNo int, lang string) string {
+	re, ok := funcNameRe[lang]
+	if !ok {
+		return ""
+	}
+
+	for i := lineNo - 1; i >= 0 && i < len(lines); i-- {
+		m := re.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		for _, name := range m[1:] {
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
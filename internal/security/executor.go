@@ -0,0 +1,151 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// AnalyzerState is a point in an analyzer's lifecycle, reported to a
+// ProgressReporter as runAnalyzers runs it.
+type AnalyzerState int
+
+const (
+	StatePending AnalyzerState = iota
+	StateRunning
+	StateDone
+	StateFailed
+	StateTimedOut
+)
+
+func (s AnalyzerState) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateRunning:
+		return "running"
+	case StateDone:
+		return "done"
+	case StateFailed:
+		return "failed"
+	case StateTimedOut:
+		return "timed out"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressReporter is notified as each configured analyzer moves through
+// its lifecycle, so a caller can render progress instead of staring at a
+// blank terminal while several tools run concurrently.
+type ProgressReporter interface {
+	Report(name string, state AnalyzerState)
+}
+
+// terminalProgressReporter prints one line per state transition to stderr.
+type terminalProgressReporter struct{}
+
+func (terminalProgressReporter) Report(name string, state AnalyzerState) {
+	fmt.Fprintf(os.Stderr, "  %s: %s\n", name, state)
+}
+
+// noopProgressReporter discards every transition; it's the default so the
+// commit-msg hook path stays quiet unless --security-verbose is passed.
+type noopProgressReporter struct{}
+
+func (noopProgressReporter) Report(string, AnalyzerState) {}
+
+const (
+	defaultAnalyzerTimeout = 30 * time.Second
+	defaultGlobalTimeout   = 2 * time.Minute
+)
+
+func analyzerTimeout(sec config.Security, name string) time.Duration {
+	if raw, ok := sec.Timeouts[name]; ok {
+		if d, err := time.ParseDuration(raw); err == nil {
+			return d
+		}
+	}
+	return defaultAnalyzerTimeout
+}
+
+func globalTimeout(sec config.Security) time.Duration {
+	if sec.GlobalTimeout != "" {
+		if d, err := time.ParseDuration(sec.GlobalTimeout); err == nil {
+			return d
+		}
+	}
+	return defaultGlobalTimeout
+}
+
+// runAnalyzers runs every named analyzer concurrently against files,
+// bounded by a global wall-clock budget (config.Security.GlobalTimeout) and
+// a per-tool timeout (config.Security.Timeouts[name]), reporting lifecycle
+// transitions to reporter. An analyzer that errors or times out contributes
+// a synthetic "analyzer-error" Finding carrying the failure in
+// Metadata["stderr"] instead of printing it, so one bad tool doesn't stop
+// the rest or spam the terminal.
+//
+// Built-in analyzers still shell out with plain exec.Command rather than
+// exec.CommandContext (changing every runX signature to thread ctx through
+// is a larger follow-up), so a timeout here stops runAnalyzers from waiting
+// on that analyzer, but doesn't kill its subprocess. Plugin analyzers do
+// honor ctx today since pluginAnalyzer.Run uses exec.CommandContext.
+func (sa *SecurityAnalyzer) runAnalyzers(ctx context.Context, names []string, files []string, reporter ProgressReporter) []Finding {
+	if reporter == nil {
+		reporter = noopProgressReporter{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, globalTimeout(sa.config.Security))
+	defer cancel()
+
+	var wg sync.WaitGroup
+	results := make(chan []Finding, len(names))
+
+	for _, name := range names {
+		name := name
+		reporter.Report(name, StatePending)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			toolCtx, toolCancel := context.WithTimeout(ctx, analyzerTimeout(sa.config.Security, name))
+			defer toolCancel()
+
+			reporter.Report(name, StateRunning)
+			findings, err := sa.runAnalyzer(toolCtx, name, files)
+			if err != nil {
+				state := StateFailed
+				if toolCtx.Err() == context.DeadlineExceeded {
+					state = StateTimedOut
+				}
+				reporter.Report(name, state)
+				results <- []Finding{{
+					Severity: "informational",
+					Type:     "analyzer-error",
+					Message:  fmt.Sprintf("%s: %v", name, err),
+					Rule:     name,
+					Metadata: map[string]interface{}{"stderr": err.Error()},
+				}}
+				return
+			}
+
+			reporter.Report(name, StateDone)
+			results <- findings
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	var all []Finding
+	for findings := range results {
+		all = append(all, findings...)
+	}
+	return all
+}
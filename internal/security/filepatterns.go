@@ -0,0 +1,182 @@
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/secrets"
+)
+
+// defaultMaxPatternFileSize bounds how much of a file analyzeFileWithPatterns
+// reads when config.Security.MaxPatternFileSize isn't set.
+const defaultMaxPatternFileSize = 5 * 1024 * 1024 // 5MB
+
+// sniffLen is how much of a file's head analyzeFileWithPatterns checks for
+// NUL bytes to decide whether it's binary, mirroring git's own heuristic.
+const sniffLen = 8192
+
+// analyzeFileWithPatterns reads filename (skipping it if it's too large, or
+// looks binary, or its content is allowlisted) and checks every line
+// against patterns, plus a talisman-style secret-detection pass over the
+// same lines. A file that fails to stat or read simply yields no findings,
+// the same way a missing/unreadable file does for runAnalyzers' other
+// scanners.
+func (sa *SecurityAnalyzer) analyzeFileWithPatterns(filename string, patterns []SecurityPattern) []Finding {
+	maxSize := sa.config.Security.MaxPatternFileSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxPatternFileSize
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil || info.Size() > maxSize {
+		return nil
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil || isBinaryContent(content) || sa.secretFileAllowlisted(content) {
+		return nil
+	}
+
+	lang := sa.detectLanguage(filename)
+	secretScanner := sa.newSecretScanner()
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	var findings []Finding
+	for i, line := range lines {
+		lineNo := i + 1
+
+		for _, p := range patterns {
+			loc := p.Pattern.FindStringIndex(line)
+			if loc == nil {
+				continue
+			}
+			if p.EntropyCheck && secrets.Entropy(line[loc[0]:loc[1]]) < secretThreshold(sa.config) {
+				continue
+			}
+			findings = append(findings, sa.languagePatternFinding(filename, lang, lineNo, lines, p))
+		}
+
+		for _, m := range secretScanner.ScanLine(filename, lineNo, line) {
+			findings = append(findings, sa.secretFileFinding(filename, lang, lineNo, lines, m))
+		}
+	}
+
+	return findings
+}
+
+// isBinaryContent sniffs the first sniffLen bytes of content for a NUL
+// byte, the same heuristic git itself (and most line-oriented scanners)
+// use to skip binary files without needing a MIME/magic-number database.
+func isBinaryContent(content []byte) bool {
+	if len(content) > sniffLen {
+		content = content[:sniffLen]
+	}
+	return bytes.IndexByte(content, 0) != -1
+}
+
+// secretFileAllowlisted reports whether content's SHA-256 checksum appears
+// in config.Security.SecretFileAllowlist, so a known fixture (a test
+// vector full of intentionally fake credentials, say) never shows up as a
+// finding no matter how many rules it happens to match.
+func (sa *SecurityAnalyzer) secretFileAllowlisted(content []byte) bool {
+	if len(sa.config.Security.SecretFileAllowlist) == 0 {
+		return false
+	}
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	for _, allowed := range sa.config.Security.SecretFileAllowlist {
+		if strings.EqualFold(allowed, checksum) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sa *SecurityAnalyzer) newSecretScanner() *secrets.Scanner {
+	return secrets.NewScanner(secretThreshold(sa.config))
+}
+
+func secretThreshold(cfg config.Config) float64 {
+	if cfg.Security.SecretEntropyThreshold > 0 {
+		return cfg.Security.SecretEntropyThreshold
+	}
+	return secrets.DefaultEntropyThreshold
+}
+
+// snippet returns the lines in [lineNo-2, lineNo+2] (1-indexed, clamped to
+// lines' bounds), joined with newlines, for Finding.Metadata["snippet"].
+func snippet(lines []string, lineNo int) string {
+	start := lineNo - 3
+	if start < 0 {
+		start = 0
+	}
+	end := lineNo + 2
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// cweNumber strips a "CWE-" prefix so it can be embedded in a
+// GITMIND-<lang>-<cwe> rule identifier without repeating "CWE".
+func cweNumber(cwe string) string {
+	return strings.TrimPrefix(cwe, "CWE-")
+}
+
+func (sa *SecurityAnalyzer) languagePatternFinding(filename, lang string, lineNo int, lines []string, p SecurityPattern) Finding {
+	return Finding{
+		Severity:   p.Severity,
+		Type:       p.Type,
+		File:       filename,
+		Line:       lineNo,
+		Message:    p.Message,
+		Rule:       fmt.Sprintf("GITMIND-%s-%s", lang, cweNumber(p.CWE)),
+		Suggestion: p.Suggestion,
+		Metadata: map[string]interface{}{
+			"cwe":     p.CWE,
+			"snippet": snippet(lines, lineNo),
+		},
+		Evidence: &Evidence{
+			Source:   lines[lineNo-1],
+			Function: enclosingFunctionName(lines, lineNo, lang),
+		},
+	}
+}
+
+// secretFileFinding mirrors scanSecrets' Finding shape, but tags the Rule
+// the same GITMIND-<lang>-<cwe> way the pattern engine's other findings do
+// (CWE-798: Use of Hard-coded Credentials) and includes a code snippet,
+// since this pass runs over whole files rather than diff hunks.
+func (sa *SecurityAnalyzer) secretFileFinding(filename, lang string, lineNo int, lines []string, m secrets.Match) Finding {
+	return Finding{
+		Severity:   secretSeverity(m),
+		Type:       "secret-" + m.Rule.Name,
+		File:       filename,
+		Line:       lineNo,
+		Message:    secretMessage(m),
+		Rule:       fmt.Sprintf("GITMIND-%s-798", lang),
+		Suggestion: secretSuggestion(m),
+		Metadata: map[string]interface{}{
+			"provider": m.Rule.Provider,
+			"verified": m.Verified,
+			"cwe":      "CWE-798",
+			"snippet":  snippet(lines, lineNo),
+		},
+		Evidence: &Evidence{
+			Source:   lines[lineNo-1],
+			Function: enclosingFunctionName(lines, lineNo, lang),
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package security
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// runForbiddenImports walks each changed .go file in ImportsOnly mode (no
+// need to parse bodies just to see the import block) and flags any import
+// matching a config.Security.ForbiddenImports rule.
+func (sa *SecurityAnalyzer) runForbiddenImports(files []string) ([]Finding, error) {
+	goFiles := sa.filterFilesByExtension(files, ".go")
+	rules := sa.config.Security.ForbiddenImports
+	if len(goFiles) == 0 || len(rules) == 0 {
+		return nil, nil
+	}
+
+	fset := token.NewFileSet()
+	var findings []Finding
+	for _, file := range goFiles {
+		f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+
+			for _, rule := range rules {
+				if rule.Pattern == "" {
+					continue
+				}
+				matched, err := path.Match(rule.Pattern, importPath)
+				if err != nil || !matched {
+					continue
+				}
+				if !matchesImportPathScope(rule.Paths, file) {
+					continue
+				}
+				findings = append(findings, forbiddenImportFinding(file, fset.Position(imp.Pos()).Line, importPath, rule))
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func forbiddenImportFinding(file string, line int, importPath string, rule config.ForbiddenImportRule) Finding {
+	message := fmt.Sprintf("import %q is forbidden by policy", importPath)
+	suggestion := "Remove this import or replace it with an approved alternative"
+	if rule.Reason != "" {
+		message += ": " + rule.Reason
+		suggestion = rule.Reason
+	}
+
+	return Finding{
+		Severity:   "medium",
+		Type:       "forbidden-import",
+		File:       file,
+		Line:       line,
+		Message:    message,
+		Rule:       rule.Pattern,
+		Suggestion: suggestion,
+	}
+}
+
+// matchesImportPathScope reports whether file is in scope for a rule's
+// Paths patterns: no patterns at all means every file is in scope; a mix
+// of plain and "!"-prefixed globs requires matching at least one plain
+// pattern (if any are given) and none of the negated ones.
+func matchesImportPathScope(paths []string, file string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+
+	hasPositive, matchedPositive := false, false
+	for _, p := range paths {
+		negate := strings.HasPrefix(p, "!")
+		pattern := strings.TrimPrefix(p, "!")
+
+		target := filepath.ToSlash(file)
+		if !strings.Contains(pattern, "/") {
+			target = filepath.Base(file)
+		}
+
+		matched, _ := path.Match(pattern, target)
+		if negate {
+			if matched {
+				return false
+			}
+			continue
+		}
+		hasPositive = true
+		if matched {
+			matchedPositive = true
+		}
+	}
+
+	return !hasPositive || matchedPositive
+}
@@ -0,0 +1,265 @@
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// runStaticcheck runs staticcheck over the changed Go files and parses its
+// line-delimited JSON output (one JSON object per finding, not a single
+// array) the same way gofmt -l output would be parsed: scan, skip blanks,
+// decode each line independently.
+func (sa *SecurityAnalyzer) runStaticcheck(files []string) ([]Finding, error) {
+	goFiles := sa.filterFilesByExtension(files, ".go")
+	if len(goFiles) == 0 {
+		return nil, nil
+	}
+
+	if !sa.isCommandAvailable("staticcheck") {
+		return nil, fmt.Errorf("staticcheck not found in PATH")
+	}
+
+	args := append([]string{"-f", "json"}, goFiles...)
+	cmd := exec.Command("staticcheck", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("staticcheck failed: %v, stderr: %s", err, stderr.String())
+		}
+	}
+
+	if stdout.Len() == 0 {
+		return nil, nil
+	}
+
+	return sa.parseStaticcheckOutput(stdout.Bytes())
+}
+
+func (sa *SecurityAnalyzer) parseStaticcheckOutput(output []byte) ([]Finding, error) {
+	var findings []Finding
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry struct {
+			Code     string `json:"code"`
+			Severity string `json:"severity"`
+			Location struct {
+				File   string `json:"file"`
+				Line   int    `json:"line"`
+				Column int    `json:"column"`
+			} `json:"location"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse staticcheck output: %v", err)
+		}
+
+		findings = append(findings, Finding{
+			Severity:   lintSeverity(entry.Severity),
+			Type:       "staticcheck-" + entry.Code,
+			File:       entry.Location.File,
+			Line:       entry.Location.Line,
+			Column:     entry.Location.Column,
+			Message:    entry.Message,
+			Rule:       entry.Code,
+			Suggestion: "Review the staticcheck documentation for " + entry.Code,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read staticcheck output: %v", err)
+	}
+
+	return findings, nil
+}
+
+// runGovet runs `go vet -json` over the package(s) containing the changed
+// Go files. Unlike the other analyzers here, vet takes package patterns
+// rather than file paths, so the changed files are mapped to their
+// containing directories ("./dir/...") first.
+func (sa *SecurityAnalyzer) runGovet(files []string) ([]Finding, error) {
+	goFiles := sa.filterFilesByExtension(files, ".go")
+	if len(goFiles) == 0 {
+		return nil, nil
+	}
+
+	if !sa.isCommandAvailable("go") {
+		return nil, fmt.Errorf("go not found in PATH")
+	}
+
+	args := append([]string{"vet", "-json"}, packageDirs(goFiles)...)
+	cmd := exec.Command("go", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// go vet reports its findings as a JSON stream on stderr, and exits
+	// non-zero whenever it finds anything -- neither is itself a failure.
+	_ = cmd.Run()
+
+	if stderr.Len() == 0 {
+		return nil, nil
+	}
+
+	return sa.parseGovetOutput(stderr.Bytes())
+}
+
+// packageDirs turns a list of changed .go file paths into a de-duplicated
+// list of "./dir/..." patterns suitable for `go vet`.
+func packageDirs(files []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, f := range files {
+		dir := "."
+		if idx := strings.LastIndex(f, "/"); idx >= 0 {
+			dir = f[:idx]
+		}
+		pattern := "./" + dir
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		dirs = append(dirs, pattern)
+	}
+	return dirs
+}
+
+func (sa *SecurityAnalyzer) parseGovetOutput(output []byte) ([]Finding, error) {
+	// go vet -json groups diagnostics as pkgPath -> analyzer -> []Diagnostic.
+	var result map[string]map[string][]struct {
+		Posn    string `json:"posn"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse go vet output: %v", err)
+	}
+
+	var findings []Finding
+	for _, analyzers := range result {
+		for analyzer, diagnostics := range analyzers {
+			for _, d := range diagnostics {
+				file, line, column := parsePosn(d.Posn)
+				findings = append(findings, Finding{
+					Severity:   "medium",
+					Type:       "govet-" + analyzer,
+					File:       file,
+					Line:       line,
+					Column:     column,
+					Message:    d.Message,
+					Rule:       analyzer,
+					Suggestion: "Review and fix the go vet " + analyzer + " finding",
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// parsePosn splits a vet-style "file:line:column" position string.
+func parsePosn(posn string) (file string, line, column int) {
+	parts := strings.Split(posn, ":")
+	if len(parts) < 3 {
+		return posn, 0, 0
+	}
+	line, _ = strconv.Atoi(parts[len(parts)-2])
+	column, _ = strconv.Atoi(parts[len(parts)-1])
+	file = strings.Join(parts[:len(parts)-2], ":")
+	return file, line, column
+}
+
+// runGolangciLint runs golangci-lint over the changed Go files and parses
+// its JSON report format.
+func (sa *SecurityAnalyzer) runGolangciLint(files []string) ([]Finding, error) {
+	goFiles := sa.filterFilesByExtension(files, ".go")
+	if len(goFiles) == 0 {
+		return nil, nil
+	}
+
+	if !sa.isCommandAvailable("golangci-lint") {
+		return nil, fmt.Errorf("golangci-lint not found in PATH")
+	}
+
+	args := append([]string{"run", "--out-format", "json"}, goFiles...)
+	cmd := exec.Command("golangci-lint", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// golangci-lint exits non-zero whenever it has findings; only treat it
+	// as a real failure when it produced nothing to parse.
+	if err := cmd.Run(); err != nil {
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("golangci-lint failed: %v, stderr: %s", err, stderr.String())
+		}
+	}
+
+	if stdout.Len() == 0 {
+		return nil, nil
+	}
+
+	return sa.parseGolangciLintOutput(stdout.Bytes())
+}
+
+func (sa *SecurityAnalyzer) parseGolangciLintOutput(output []byte) ([]Finding, error) {
+	var result struct {
+		Issues []struct {
+			FromLinter string `json:"FromLinter"`
+			Text       string `json:"Text"`
+			Severity   string `json:"Severity"`
+			Pos        struct {
+				Filename string `json:"Filename"`
+				Line     int    `json:"Line"`
+				Column   int    `json:"Column"`
+			} `json:"Pos"`
+		} `json:"Issues"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse golangci-lint output: %v", err)
+	}
+
+	var findings []Finding
+	for _, issue := range result.Issues {
+		findings = append(findings, Finding{
+			Severity:   lintSeverity(issue.Severity),
+			Type:       "golangci-lint-" + issue.FromLinter,
+			File:       issue.Pos.Filename,
+			Line:       issue.Pos.Line,
+			Column:     issue.Pos.Column,
+			Message:    issue.Text,
+			Rule:       issue.FromLinter,
+			Suggestion: "Review and fix the " + issue.FromLinter + " finding",
+		})
+	}
+
+	return findings, nil
+}
+
+// lintSeverity normalizes a linter's own severity label to gitmind's
+// "high"/"medium"/"low" scale; linters that don't report one at all (vet,
+// most staticcheck/golangci-lint findings) default to "medium" rather than
+// being silently dropped.
+func lintSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error":
+		return "high"
+	case "warning":
+		return "medium"
+	case "info", "information", "suggestion":
+		return "low"
+	default:
+		return "medium"
+	}
+}
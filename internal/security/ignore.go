@@ -0,0 +1,166 @@
+package security
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+)
+
+// Status values mirror the vocabulary CSAF/OSV use for a vulnerability's
+// disposition. A Finding with no Status set is equivalent to
+// StatusUnknown; only applyIgnoreEntries ever sets one explicitly.
+const (
+	StatusUnknown            = "unknown"
+	StatusAffected           = "affected"
+	StatusNotAffected        = "not_affected"
+	StatusFixed              = "fixed"
+	StatusUnderInvestigation = "under_investigation"
+	StatusWillNotFix         = "will_not_fix"
+	StatusFixDeferred        = "fix_deferred"
+	StatusEndOfLife          = "end_of_life"
+)
+
+// DefaultIgnorePath is used when config.Security.IgnorePath is empty.
+const DefaultIgnorePath = ".gitmindignore"
+
+// IgnoreEntry is one suppression loaded from a .gitmindignore file or
+// config.Security.Ignore: a finding whose Rule equals ID, or whose Type
+// contains ID anywhere (so a CVE/advisory ID embedded in a dependency
+// finding's Type, e.g. "safety-CVE-2021-1234", still matches on just the
+// CVE), is recorded under Status instead of counted as an open finding.
+type IgnoreEntry struct {
+	ID     string
+	Status string
+	Reason string
+	Expiry string
+}
+
+// LoadIgnoreFile parses a trivyignore-style ignore file -- one entry per
+// line, blank lines and lines starting with '#' are skipped -- and appends
+// the result to sa's existing ignore entries (seeded from
+// config.Security.Ignore by New), so a .gitmindignore file and inline
+// gitmind.yaml rules both apply. A missing file is not an error -- most
+// repos adopting gitmind won't have one yet.
+//
+// Each line has the shape:
+//
+//	<id> [status=<status>] [expiry=YYYY-MM-DD]  # optional reason
+//
+// status defaults to "will_not_fix" when omitted, matching .trivyignore's
+// convention that a bare entry just silences the finding.
+func (sa *SecurityAnalyzer) LoadIgnoreFile(path string) error {
+	entries, err := parseIgnoreFile(path)
+	if err != nil {
+		return err
+	}
+	sa.ignoreEntries = append(sa.ignoreEntries, entries...)
+	return nil
+}
+
+func parseIgnoreFile(path string) ([]IgnoreEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []IgnoreEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		fieldsPart, reason := line, ""
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			fieldsPart, reason = line[:idx], strings.TrimSpace(line[idx+1:])
+		}
+
+		fields := strings.Fields(fieldsPart)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry := IgnoreEntry{ID: fields[0], Status: StatusWillNotFix, Reason: reason}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "status":
+				entry.Status = value
+			case "expiry":
+				entry.Expiry = value
+			}
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// applyIgnoreEntries splits findings into kept (still counted as open) and
+// ignored (matched an entry, with Status set accordingly). Unlike
+// filterBaseline, an ignored finding is never silently dropped -- it's
+// returned so AnalyzeDiff can still surface it via SecurityReport.Ignored.
+func (sa *SecurityAnalyzer) applyIgnoreEntries(findings []Finding) (kept, ignored []Finding) {
+	kept = make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		entry, ok := matchIgnoreEntry(f, sa.ignoreEntries)
+		if !ok {
+			kept = append(kept, f)
+			continue
+		}
+		f.Status = entry.Status
+		ignored = append(ignored, f)
+	}
+	return kept, ignored
+}
+
+func matchIgnoreEntry(f Finding, entries []IgnoreEntry) (IgnoreEntry, bool) {
+	for _, e := range entries {
+		if e.Expiry != "" {
+			if expiry, err := time.Parse("2006-01-02", e.Expiry); err == nil && time.Now().After(expiry) {
+				continue
+			}
+		}
+		if e.ID != "" && (e.ID == f.Rule || strings.Contains(f.Type, e.ID)) {
+			return e, true
+		}
+	}
+	return IgnoreEntry{}, false
+}
+
+// FilterByStatus returns the findings whose Status (StatusUnknown when
+// empty) appears in statuses, for the CLI's --status flag. An empty
+// statuses list matches everything.
+func FilterByStatus(findings []Finding, statuses []string) []Finding {
+	if len(statuses) == 0 {
+		return findings
+	}
+
+	allowed := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		allowed[strings.TrimSpace(s)] = true
+	}
+
+	filtered := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		status := f.Status
+		if status == "" {
+			status = StatusUnknown
+		}
+		if allowed[status] {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
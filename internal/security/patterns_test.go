@@ -0,0 +1,104 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+func TestAnalyzePatternsDetectsHardcodedPassword(t *testing.T) {
+	sa := New(config.Config{})
+	diff := `diff --git a/app.go b/app.go
+index 1111111..2222222 100644
+--- a/app.go
++++ b/app.go
+@@ -1,2 +1,3 @@
+ package app
++password = "hunter2"
+`
+	findings := sa.analyzePatterns(diff, []string{"app.go"})
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	if findings[0].Type != "hardcoded-password" {
+		t.Fatalf("finding type %q, want hardcoded-password", findings[0].Type)
+	}
+	if findings[0].Severity != "high" {
+		t.Fatalf("finding severity %q, want high", findings[0].Severity)
+	}
+	if findings[0].File != "app.go" || findings[0].Line != 2 {
+		t.Fatalf("finding location = %s:%d, want app.go:2", findings[0].File, findings[0].Line)
+	}
+}
+
+func TestAnalyzePatternsIgnoresRemovedLines(t *testing.T) {
+	sa := New(config.Config{})
+	diff := `diff --git a/app.go b/app.go
+index 1111111..2222222 100644
+--- a/app.go
++++ b/app.go
+@@ -1,2 +1,1 @@
+ package app
+-password = "hunter2"
+`
+	findings := sa.analyzePatterns(diff, []string{"app.go"})
+	if len(findings) != 0 {
+		t.Fatalf("pattern engine should only scan added lines, got %+v", findings)
+	}
+}
+
+func TestAnalyzePatternsIgnoresCleanDiff(t *testing.T) {
+	sa := New(config.Config{})
+	diff := `diff --git a/app.go b/app.go
+index 1111111..2222222 100644
+--- a/app.go
++++ b/app.go
+@@ -1,2 +1,3 @@
+ package app
++fmt.Println("starting up")
+`
+	findings := sa.analyzePatterns(diff, []string{"app.go"})
+	if len(findings) != 0 {
+		t.Fatalf("got %d findings on an unremarkable diff, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestAnalyzePatternsSkipsBinaryFiles(t *testing.T) {
+	sa := New(config.Config{})
+	diff := `diff --git a/image.png b/image.png
+index 3333333..4444444 100644
+Binary files a/image.png and b/image.png differ
+`
+	findings := sa.analyzePatterns(diff, []string{"image.png"})
+	if len(findings) != 0 {
+		t.Fatalf("got %d findings on a binary file, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestAnalyzePatternsDetectsMultipleDistinctPatterns(t *testing.T) {
+	sa := New(config.Config{})
+	diff := `diff --git a/app.go b/app.go
+index 1111111..2222222 100644
+--- a/app.go
++++ b/app.go
+@@ -1,1 +1,3 @@
+ package app
++api_key = "abc123"
++resp, _ := http.Get("http://example.com")
+`
+	findings := sa.analyzePatterns(diff, []string{"app.go"})
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2: %+v", len(findings), findings)
+	}
+	var types []string
+	for _, f := range findings {
+		types = append(types, f.Type)
+	}
+	if !strings.Contains(strings.Join(types, ","), "hardcoded-secret") {
+		t.Fatalf("expected a hardcoded-secret finding, got types %v", types)
+	}
+	if !strings.Contains(strings.Join(types, ","), "insecure-protocol") {
+		t.Fatalf("expected an insecure-protocol finding, got types %v", types)
+	}
+}
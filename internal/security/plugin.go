@@ -0,0 +1,67 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// pluginAnalyzer runs an external scanner declared in
+// config.Security.Plugins. Contract: the files to analyze are written to
+// the process's stdin as a JSON array of strings, and its findings are
+// read back from stdout, decoded according to cfg.Format.
+type pluginAnalyzer struct {
+	cfg config.AnalyzerPlugin
+	sa  *SecurityAnalyzer
+}
+
+func newPluginAnalyzer(sa *SecurityAnalyzer, cfg config.AnalyzerPlugin) Analyzer {
+	return pluginAnalyzer{cfg: cfg, sa: sa}
+}
+
+func (p pluginAnalyzer) Name() string { return p.cfg.Name }
+
+// Supports offers every file to the plugin; gitmind has no way to know
+// what languages an arbitrary external scanner handles, so filtering is
+// left to the plugin itself.
+func (p pluginAnalyzer) Supports(file string) bool { return true }
+
+func (p pluginAnalyzer) Run(ctx context.Context, files []string) ([]Finding, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	stdin, err := json.Marshal(files)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: encoding file list: %w", p.cfg.Name, err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.cfg.Command, p.cfg.Args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		return nil, fmt.Errorf("plugin %s failed: %v, stderr: %s", p.cfg.Name, err, stderr.String())
+	}
+
+	switch p.cfg.Format {
+	case "sarif":
+		return p.sa.parseSARIFOutput(stdout.Bytes(), p.cfg.Name)
+	case "semgrep":
+		return p.sa.parseSemgrepOutput(stdout.Bytes())
+	case "json", "":
+		var findings []Finding
+		if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+			return nil, fmt.Errorf("plugin %s: decoding json findings: %w", p.cfg.Name, err)
+		}
+		return findings, nil
+	default:
+		return nil, fmt.Errorf("plugin %s: unknown format %q", p.cfg.Name, p.cfg.Format)
+	}
+}
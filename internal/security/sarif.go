@@ -0,0 +1,295 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SARIF 2.1.0 (https://docs.oasis-open.org/sarif/sarif/v2.1.0/) is the
+// format GitHub code scanning, and most other CI security dashboards,
+// expect. These types cover just the subset ExportSARIF/parseSARIFOutput
+// round-trip; they are not a general-purpose SARIF library.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifToolPrefixes maps a Finding.Type's leading "<tool>-" prefix to the
+// tool that produced it, for grouping ExportSARIF's findings into one SARIF
+// run per tool (rather than a single run misattributed to "gitmind").
+var sarifToolPrefixes = []string{
+	"cargo-audit-", "gosec-", "bandit-", "eslint-", "semgrep-", "safety-",
+	"brakeman-", "spotbugs-", "psalm-", "phpstan-", "cppcheck-",
+	"flawfinder-", "clippy-", "secret-",
+}
+
+// sarifToolName returns the name of the SARIF run a finding belongs in:
+// the external analyzer that produced it, "gitmind-patterns" for the
+// built-in pattern engine, or "gitmind" for anything else (e.g. a
+// synthetic analyzer-error finding).
+func sarifToolName(f Finding) string {
+	if f.Rule == "pattern-analysis" {
+		return "gitmind-patterns"
+	}
+	for _, prefix := range sarifToolPrefixes {
+		if strings.HasPrefix(f.Type, prefix) {
+			return strings.TrimSuffix(prefix, "-")
+		}
+	}
+	return "gitmind"
+}
+
+// sarifProperties merges finding's Metadata with its Evidence (under an
+// "evidence" key) into the map ExportSARIF attaches to a SARIF result, so
+// a viewer that surfaces result properties still shows reproduction detail
+// instead of just Metadata. Returns finding.Metadata unmodified when
+// there's no Evidence, and never mutates it.
+func sarifProperties(finding Finding) map[string]interface{} {
+	if finding.Evidence == nil {
+		return finding.Metadata
+	}
+
+	properties := make(map[string]interface{}, len(finding.Metadata)+1)
+	for k, v := range finding.Metadata {
+		properties[k] = v
+	}
+	properties["evidence"] = finding.Evidence
+	return properties
+}
+
+// DefaultSarifPath is used when config.Security.SarifPath is empty.
+const DefaultSarifPath = "gitmind-security.sarif"
+
+// ExportSARIF serializes findings into a SARIF 2.1.0 log with one run per
+// originating tool (sarifToolName), so a viewer that groups by run -- e.g.
+// GitHub code scanning -- shows gosec, semgrep, cppcheck, etc. as distinct
+// sources instead of collapsing everything under "gitmind". Each rule's
+// FullDescription reuses the finding's own Suggestion (already populated
+// per-tool by getGosecSuggestion/getBanditSuggestion/...), and each
+// result's Properties carries the finding's Metadata.
+func (sa *SecurityAnalyzer) ExportSARIF(findings []Finding) ([]byte, error) {
+	type runBuilder struct {
+		rules     []sarifRule
+		seenRules map[string]bool
+		results   []sarifResult
+	}
+	runs := make(map[string]*runBuilder)
+	var toolOrder []string
+
+	for _, finding := range findings {
+		tool := sarifToolName(finding)
+		rb, ok := runs[tool]
+		if !ok {
+			rb = &runBuilder{seenRules: make(map[string]bool)}
+			runs[tool] = rb
+			toolOrder = append(toolOrder, tool)
+		}
+
+		ruleID := finding.Rule
+		if ruleID == "" {
+			ruleID = finding.Type
+		}
+
+		if !rb.seenRules[ruleID] {
+			rb.seenRules[ruleID] = true
+			rb.rules = append(rb.rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifMessage{Text: finding.Type},
+				FullDescription:  sarifMessage{Text: finding.Suggestion},
+			})
+		}
+
+		rb.results = append(rb.results, sarifResult{
+			RuleID:  ruleID,
+			Level:   severityToSARIFLevel(finding.Severity),
+			Message: sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: finding.File},
+						Region: sarifRegion{
+							StartLine:   finding.Line,
+							StartColumn: finding.Column,
+						},
+					},
+				},
+			},
+			Properties: sarifProperties(finding),
+		})
+	}
+
+	runsOut := make([]sarifRun, 0, len(toolOrder))
+	for _, tool := range toolOrder {
+		rb := runs[tool]
+		runsOut = append(runsOut, sarifRun{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:  tool,
+					Rules: rb.rules,
+				},
+			},
+			Results: rb.results,
+		})
+	}
+
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Runs:    runsOut,
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// ImportSARIF decodes a SARIF 2.1.0 log produced by an external scanner
+// (e.g. uploaded as a CI artifact) into Findings, tagging them as having
+// come from toolName the same way a built-in analyzer's parser would, so
+// MergeFindings can fold them into a report's Summary.
+func (sa *SecurityAnalyzer) ImportSARIF(data []byte, toolName string) ([]Finding, error) {
+	return sa.parseSARIFOutput(data, toolName)
+}
+
+// MergeFindings combines report's findings with extra (e.g. from
+// ImportSARIF), applying the ignore-entry lifecycle to extra the same way
+// AnalyzeDiff would, then recomputing Summary and Suggestions so the merged
+// set stays internally consistent rather than just concatenating raw
+// findings.
+func (sa *SecurityAnalyzer) MergeFindings(report *SecurityReport, extra []Finding) *SecurityReport {
+	kept, ignored := sa.applyIgnoreEntries(extra)
+
+	findings := make([]Finding, 0, len(report.Findings)+len(kept))
+	findings = append(findings, report.Findings...)
+	findings = append(findings, kept...)
+
+	allIgnored := make([]Finding, 0, len(report.Ignored)+len(ignored))
+	allIgnored = append(allIgnored, report.Ignored...)
+	allIgnored = append(allIgnored, ignored...)
+
+	return &SecurityReport{
+		Findings:    findings,
+		Ignored:     allIgnored,
+		Summary:     sa.generateSummary(findings),
+		Suggestions: sa.generateSuggestions(findings),
+	}
+}
+
+// parseSARIFOutput decodes a SARIF 2.1.0 log produced by any analyzer that
+// supports the format natively (flawfinder's --sarif flag, semgrep's
+// --sarif flag, ESLint's @microsoft/eslint-formatter-sarif, ...), so those
+// analyzers don't need their own bespoke parser. tool prefixes Finding.Type
+// the same way the per-tool parsers do (e.g. "flawfinder-CWE-120").
+func (sa *SecurityAnalyzer) parseSARIFOutput(output []byte, tool string) ([]Finding, error) {
+	var log sarifLog
+	if err := json.Unmarshal(output, &log); err != nil {
+		return nil, fmt.Errorf("failed to parse %s SARIF output: %v", tool, err)
+	}
+
+	var findings []Finding
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			finding := Finding{
+				Severity: sarifLevelToSeverity(result.Level),
+				Type:     tool + "-" + result.RuleID,
+				Message:  result.Message.Text,
+				Rule:     result.RuleID,
+			}
+
+			if len(result.Locations) > 0 {
+				region := result.Locations[0].PhysicalLocation.Region
+				finding.File = result.Locations[0].PhysicalLocation.ArtifactLocation.URI
+				finding.Line = region.StartLine
+				finding.Column = region.StartColumn
+			}
+
+			if cwe := sarifCWEFromTags(result.Properties); cwe != "" {
+				finding.Metadata = map[string]interface{}{"cwe": cwe}
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// severityToSARIFLevel maps gitmind's internal severity to SARIF's result
+// level vocabulary ("error", "warning", "note", "none").
+func severityToSARIFLevel(severity string) string {
+	switch severity {
+	case "high":
+		return "error"
+	case "medium":
+		return "warning"
+	case "low":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// sarifLevelToSeverity is the inverse of severityToSARIFLevel, used when
+// consuming SARIF produced by an external tool.
+func sarifLevelToSeverity(level string) string {
+	switch level {
+	case "error":
+		return "high"
+	case "warning":
+		return "medium"
+	case "note":
+		return "low"
+	default:
+		return "medium"
+	}
+}
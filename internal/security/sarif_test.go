@@ -0,0 +1,120 @@
+package security
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+func TestExportSARIFGroupsByTool(t *testing.T) {
+	sa := New(config.Config{})
+	findings := []Finding{
+		{Severity: "high", Type: "hardcoded-password", File: "app.go", Line: 3, Rule: "pattern-analysis", Suggestion: "use env vars"},
+		{Severity: "medium", Type: "gosec-G101", File: "app.go", Line: 9, Rule: "G101", Suggestion: "avoid hardcoded creds"},
+	}
+
+	data, err := sa.ExportSARIF(findings)
+	if err != nil {
+		t.Fatalf("ExportSARIF: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("ExportSARIF output didn't parse as JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Fatalf("log.Version = %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 2 {
+		t.Fatalf("got %d runs, want 2 (one per tool): %+v", len(log.Runs), log.Runs)
+	}
+
+	names := map[string]bool{}
+	for _, run := range log.Runs {
+		names[run.Tool.Driver.Name] = true
+	}
+	if !names["gitmind-patterns"] {
+		t.Fatalf("expected a gitmind-patterns run, got %+v", names)
+	}
+	if !names["gosec"] {
+		t.Fatalf("expected a gosec run, got %+v", names)
+	}
+}
+
+func TestExportSARIFResultFields(t *testing.T) {
+	sa := New(config.Config{})
+	findings := []Finding{
+		{Severity: "high", Type: "hardcoded-password", File: "app.go", Line: 3, Column: 5, Message: "Hardcoded password detected", Rule: "pattern-analysis"},
+	}
+
+	data, err := sa.ExportSARIF(findings)
+	if err != nil {
+		t.Fatalf("ExportSARIF: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected exactly one run with one result, got %+v", log.Runs)
+	}
+	result := log.Runs[0].Results[0]
+	if result.Level != "error" {
+		t.Fatalf("severity 'high' should map to SARIF level 'error', got %q", result.Level)
+	}
+	if result.Message.Text != "Hardcoded password detected" {
+		t.Fatalf("result message = %q, want the finding's Message", result.Message.Text)
+	}
+	loc := result.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "app.go" || loc.Region.StartLine != 3 || loc.Region.StartColumn != 5 {
+		t.Fatalf("result location = %+v, want app.go:3:5", loc)
+	}
+}
+
+func TestImportSARIFRoundTrip(t *testing.T) {
+	sa := New(config.Config{})
+	original := []Finding{
+		{Severity: "high", Type: "hardcoded-password", File: "app.go", Line: 3, Message: "Hardcoded password detected", Rule: "pattern-analysis"},
+	}
+
+	data, err := sa.ExportSARIF(original)
+	if err != nil {
+		t.Fatalf("ExportSARIF: %v", err)
+	}
+
+	imported, err := sa.ImportSARIF(data, "external-tool")
+	if err != nil {
+		t.Fatalf("ImportSARIF: %v", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("got %d imported findings, want 1: %+v", len(imported), imported)
+	}
+	if imported[0].Severity != "high" {
+		t.Fatalf("imported severity = %q, want high", imported[0].Severity)
+	}
+	if imported[0].File != "app.go" || imported[0].Line != 3 {
+		t.Fatalf("imported location = %s:%d, want app.go:3", imported[0].File, imported[0].Line)
+	}
+}
+
+func TestMergeFindingsCombinesAndRecomputesSummary(t *testing.T) {
+	sa := New(config.Config{})
+	report := &SecurityReport{
+		Findings: []Finding{{Severity: "low", Type: "insecure-protocol"}},
+	}
+	extra := []Finding{{Severity: "high", Type: "hardcoded-password"}}
+
+	merged := sa.MergeFindings(report, extra)
+	if len(merged.Findings) != 2 {
+		t.Fatalf("got %d merged findings, want 2: %+v", len(merged.Findings), merged.Findings)
+	}
+	if merged.Summary.TotalFindings != 2 {
+		t.Fatalf("Summary.TotalFindings = %d, want 2", merged.Summary.TotalFindings)
+	}
+	if merged.Summary.HighSeverity != 1 || merged.Summary.LowSeverity != 1 {
+		t.Fatalf("Summary severity counts = %+v, want 1 high and 1 low", merged.Summary)
+	}
+}
@@ -0,0 +1,88 @@
+package security
+
+import (
+	"fmt"
+
+	gitdiff "github.com/Harri200191/gitmind/internal/diff"
+	"github.com/Harri200191/gitmind/internal/secrets"
+)
+
+// secretSeverity maps a secrets.Match to gitmind's severity vocabulary. A
+// rule match (a recognized credential format) is high risk; an unmatched
+// high-entropy string is a much weaker signal and stays at medium.
+func secretSeverity(m secrets.Match) string {
+	if m.Rule.Name == "secret-generic" {
+		return "medium"
+	}
+	return "high"
+}
+
+func secretMessage(m secrets.Match) string {
+	if m.Rule.Name == "secret-generic" {
+		return fmt.Sprintf("High-entropy string detected (possible secret, entropy %.1f bits/char)", m.Entropy)
+	}
+	if m.Verified {
+		return fmt.Sprintf("%s %s detected and confirmed active", m.Rule.Provider, m.Rule.KeyType)
+	}
+	return fmt.Sprintf("%s %s detected", m.Rule.Provider, m.Rule.KeyType)
+}
+
+func secretSuggestion(m secrets.Match) string {
+	if m.Rule.RevocationURL != "" {
+		return "Revoke this credential immediately: " + m.Rule.RevocationURL
+	}
+	return "Remove the secret from history and rotate the credential"
+}
+
+// scanSecrets runs the secrets rule pack (plus entropy fallback) over every
+// added line of diff. It's called from AnalyzeDiff ahead of the (slower,
+// subprocess-based) language analyzers, so a leaked credential is available
+// to ShouldBlockCommit without waiting on gosec/semgrep/etc.
+func (sa *SecurityAnalyzer) scanSecrets(diff string) []Finding {
+	threshold := sa.config.Security.SecretEntropyThreshold
+	if threshold <= 0 {
+		threshold = secrets.DefaultEntropyThreshold
+	}
+	scanner := secrets.NewScanner(threshold)
+
+	parsed, err := gitdiff.Parse(diff)
+	if err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, fd := range parsed {
+		if fd.IsBinary {
+			continue
+		}
+		for _, line := range fd.AddedLines() {
+			for _, m := range scanner.ScanLine(fd.Path(), line.NewLineNo, line.Text) {
+				findings = append(findings, Finding{
+					Severity:   secretSeverity(m),
+					Type:       "secret-" + m.Rule.Name,
+					File:       fd.Path(),
+					Line:       line.NewLineNo,
+					Message:    secretMessage(m),
+					Rule:       m.Rule.Name,
+					Suggestion: secretSuggestion(m),
+					Metadata: map[string]interface{}{
+						"provider": m.Rule.Provider,
+						"verified": m.Verified,
+					},
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// hasHighSeverity reports whether any finding is high severity.
+func hasHighSeverity(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == "high" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package security
+
+import "strings"
+
+// parsedVersion is a (major, minor, patch) triple -- enough to order the
+// version strings dependency advisories actually carry (cargo-audit's
+// patched_versions, safety's vulnerable_spec). It is not a full semver
+// implementation: no pre-release/build-metadata ordering, since neither
+// advisory source needs it.
+type parsedVersion struct {
+	major, minor, patch int
+}
+
+func parseVersion(v string) (parsedVersion, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v = strings.TrimPrefix(v, "=")
+	if v == "" {
+		return parsedVersion{}, false
+	}
+
+	parts := strings.SplitN(v, ".", 3)
+	var pv parsedVersion
+	var ok bool
+	if pv.major, ok = atoiPrefix(parts[0]); !ok {
+		return pv, false
+	}
+	if len(parts) > 1 {
+		pv.minor, _ = atoiPrefix(parts[1])
+	}
+	if len(parts) > 2 {
+		pv.patch, _ = atoiPrefix(parts[2])
+	}
+	return pv, true
+}
+
+// atoiPrefix parses the leading run of digits in s, so a version component
+// like "4-beta" or "4+build" still yields 4 instead of failing outright.
+func atoiPrefix(s string) (int, bool) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range s[:i] {
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+func (a parsedVersion) compare(b parsedVersion) int {
+	if a.major != b.major {
+		return a.major - b.major
+	}
+	if a.minor != b.minor {
+		return a.minor - b.minor
+	}
+	return a.patch - b.patch
+}
+
+// versionSatisfiesConstraint checks version against one constraint token
+// like ">=1.2.0", "<2.0.0", "=1.0.0", or a bare "1.0.0" (treated as "=").
+func versionSatisfiesConstraint(version, constraint string) bool {
+	constraint = strings.TrimSpace(constraint)
+
+	op, rest := "=", constraint
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(constraint, candidate) {
+			op, rest = candidate, strings.TrimPrefix(constraint, candidate)
+			break
+		}
+	}
+
+	v, vOK := parseVersion(version)
+	c, cOK := parseVersion(rest)
+	if !vOK || !cOK {
+		// Can't parse one side -- don't let an unrecognized version format
+		// silently drop a finding that might well apply.
+		return true
+	}
+
+	switch cmp := v.compare(c); op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// versionSatisfiesRange checks version against a comma-separated list of
+// constraints (all must hold), the shape cargo-audit's patched_versions and
+// safety's vulnerable_spec both use (e.g. ">=1.2.0,<2.0.0"). An empty range
+// is treated as satisfied by everything, since there's nothing to compare.
+func versionSatisfiesRange(version, rng string) bool {
+	for _, constraint := range strings.Split(rng, ",") {
+		constraint = strings.TrimSpace(constraint)
+		if constraint == "" {
+			continue
+		}
+		if !versionSatisfiesConstraint(version, constraint) {
+			return false
+		}
+	}
+	return true
+}
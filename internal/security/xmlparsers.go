@@ -0,0 +1,155 @@
+package security
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// spotbugsBugCollection is the subset of SpotBugs' `-xml` output
+// parseSpotBugsOutput needs: <BugCollection><BugInstance type="..."
+// priority="..." category="..."><SourceLine sourcepath="..." start="..."/>
+// </BugInstance></BugCollection>.
+type spotbugsBugCollection struct {
+	Bugs []spotbugsBugInstance `xml:"BugInstance"`
+}
+
+type spotbugsBugInstance struct {
+	Type         string             `xml:"type,attr"`
+	Priority     string             `xml:"priority,attr"`
+	Category     string             `xml:"category,attr"`
+	LongMessage  string             `xml:"LongMessage"`
+	ShortMessage string             `xml:"ShortMessage"`
+	SourceLine   spotbugsSourceLine `xml:"SourceLine"`
+}
+
+type spotbugsSourceLine struct {
+	SourcePath string `xml:"sourcepath,attr"`
+	Start      string `xml:"start,attr"`
+}
+
+// spotbugsPriorityToSeverity maps SpotBugs' 1 (highest) - 3 (lowest)
+// priority scale onto gitmind's severity vocabulary.
+func spotbugsPriorityToSeverity(priority string) string {
+	switch priority {
+	case "1":
+		return "high"
+	case "2":
+		return "medium"
+	case "3":
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+func (sa *SecurityAnalyzer) parseSpotBugsOutput(output []byte) ([]Finding, error) {
+	var collection spotbugsBugCollection
+	if err := xml.Unmarshal(output, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse spotbugs output: %v", err)
+	}
+
+	var findings []Finding
+	for _, bug := range collection.Bugs {
+		message := bug.LongMessage
+		if message == "" {
+			message = bug.ShortMessage
+		}
+		if message == "" {
+			message = fmt.Sprintf("%s (%s)", bug.Type, bug.Category)
+		}
+
+		line, _ := atoiPrefix(bug.SourceLine.Start)
+		findings = append(findings, Finding{
+			Severity:   spotbugsPriorityToSeverity(bug.Priority),
+			Type:       "spotbugs-" + bug.Type,
+			File:       bug.SourceLine.SourcePath,
+			Line:       line,
+			Message:    message,
+			Rule:       bug.Type,
+			Suggestion: "Review SpotBugs finding and remediate per category " + bug.Category,
+			Metadata: map[string]interface{}{
+				"category": bug.Category,
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+// cppcheckResults is the subset of CppCheck's `--xml` (schema version 2)
+// output parseCppCheckOutput needs: <results version="2"><errors><error
+// id="..." severity="..." msg="..." cwe="..."><location file="..."
+// line="..."/></error></errors></results>.
+type cppcheckResults struct {
+	Errors []cppcheckError `xml:"errors>error"`
+}
+
+type cppcheckError struct {
+	ID        string             `xml:"id,attr"`
+	Severity  string             `xml:"severity,attr"`
+	Message   string             `xml:"msg,attr"`
+	CWE       string             `xml:"cwe,attr"`
+	Locations []cppcheckLocation `xml:"location"`
+}
+
+type cppcheckLocation struct {
+	File string `xml:"file,attr"`
+	Line string `xml:"line,attr"`
+}
+
+func (sa *SecurityAnalyzer) parseCppCheckOutput(output []byte) ([]Finding, error) {
+	var results cppcheckResults
+	if err := xml.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse cppcheck output: %v", err)
+	}
+
+	var findings []Finding
+	for _, e := range results.Errors {
+		file, line := "unknown", 0
+		if len(e.Locations) > 0 {
+			file = e.Locations[0].File
+			line, _ = atoiPrefix(e.Locations[0].Line)
+		}
+
+		metadata := map[string]interface{}{}
+		if e.CWE != "" {
+			metadata["cwe"] = "CWE-" + e.CWE
+		}
+
+		findings = append(findings, Finding{
+			Severity:   e.Severity,
+			Type:       "cppcheck-" + e.ID,
+			File:       file,
+			Line:       line,
+			Message:    e.Message,
+			Rule:       e.ID,
+			Suggestion: "Review and fix C/C++ issue: " + e.Message,
+			Metadata:   metadata,
+		})
+	}
+
+	return findings, nil
+}
+
+// sarifCWEFromTags pulls a "CWE-<n>" identifier out of a SARIF result's
+// properties.tags array (the shape flawfinder's --sarif output uses, e.g.
+// tags: ["external/cwe/cwe-120"]), or "" if none of the tags look like one.
+func sarifCWEFromTags(properties map[string]interface{}) string {
+	tags, ok := properties["tags"].([]interface{})
+	if !ok {
+		return ""
+	}
+	for _, t := range tags {
+		tag, ok := t.(string)
+		if !ok {
+			continue
+		}
+		idx := strings.LastIndex(strings.ToLower(tag), "cwe-")
+		if idx == -1 {
+			continue
+		}
+		return "CWE-" + tag[idx+len("cwe-"):]
+	}
+	return ""
+}
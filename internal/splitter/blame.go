@@ -0,0 +1,183 @@
+package splitter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Harri200191/gitmind/internal/gitexec"
+)
+
+// BlameLine is one line of a file as reported by `git blame`: which
+// commit last introduced it, and that commit's author/subject/age.
+type BlameLine struct {
+	Commit  string
+	Author  string
+	Subject string
+	Age     string
+}
+
+// BlameAttribution summarizes how many of a change's removed/modified
+// lines trace back to a single prior commit, ordered by Lines descending.
+type BlameAttribution struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+	Author  string `json:"author"`
+	Age     string `json:"age"`
+	Lines   int    `json:"lines"`
+}
+
+var (
+	blameCacheMu sync.Mutex
+	blameCache   = map[string][]BlameLine{}
+)
+
+var blameHeaderRe = regexp.MustCompile(`^([0-9a-f]{40}) (\d+) (\d+)`)
+
+// blameFile runs `git blame` against file as of rev, caching the result
+// in-memory per (file, rev) pair for the lifetime of the process so a
+// diff touching the same file in several hunks only pays the cost once.
+//
+// NOTE: once github.com/go-git/go-git/v5 is vendored this should call its
+// Blame API directly instead of shelling out to `git blame --porcelain`;
+// until then it goes through internal/gitexec like every other package
+// that shells out to the git binary.
+func blameFile(file, rev string) ([]BlameLine, error) {
+	key := rev + ":" + file
+
+	blameCacheMu.Lock()
+	cached, ok := blameCache[key]
+	blameCacheMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	out, err := gitexec.New("blame").AddFlags("--porcelain").AddDynamic(rev).AddPaths(file).Run(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("git blame %s: %w", file, err)
+	}
+
+	lines := parseBlamePorcelain(out)
+
+	blameCacheMu.Lock()
+	blameCache[key] = lines
+	blameCacheMu.Unlock()
+
+	return lines, nil
+}
+
+// parseBlamePorcelain decodes `git blame --porcelain` output into one
+// BlameLine per line of the blamed file. The porcelain format prints full
+// commit metadata (author, author-time, summary, ...) only the first time
+// a commit is referenced; subsequent lines from the same commit carry just
+// the header, so metadata is cached by SHA as it's seen.
+func parseBlamePorcelain(output string) []BlameLine {
+	var result []BlameLine
+	metaBySHA := make(map[string]BlameLine)
+
+	var cur BlameLine
+	var curSHA string
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := blameHeaderRe.FindStringSubmatch(line); m != nil {
+			curSHA = m[1]
+			if known, ok := metaBySHA[curSHA]; ok {
+				cur = known
+			} else {
+				cur = BlameLine{Commit: curSHA}
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "author "):
+			cur.Author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				cur.Age = formatBlameAge(ts)
+			}
+		case strings.HasPrefix(line, "summary "):
+			cur.Subject = strings.TrimPrefix(line, "summary ")
+		case strings.HasPrefix(line, "\t"):
+			metaBySHA[curSHA] = cur
+			result = append(result, cur)
+		}
+	}
+
+	return result
+}
+
+func formatBlameAge(unixSeconds int64) string {
+	d := time.Since(time.Unix(unixSeconds, 0))
+	switch {
+	case d < 24*time.Hour:
+		return "today"
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%d day(s) ago", int(d.Hours()/24))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%d week(s) ago", int(d.Hours()/(24*7)))
+	default:
+		return fmt.Sprintf("%d month(s) ago", int(d.Hours()/(24*30)))
+	}
+}
+
+// blameAttributionForFilePatch blames fp's pre-image at HEAD and attributes
+// every line a Delete operation removes to the commit that last touched it,
+// returning one BlameAttribution per distinct commit, most-affected first.
+func blameAttributionForFilePatch(fp FilePatch) []BlameAttribution {
+	if fp.IsNew || fp.IsBinary || fp.FromFile == "" {
+		return nil
+	}
+
+	blamed, err := blameFile(fp.FromFile, "HEAD")
+	if err != nil || len(blamed) == 0 {
+		return nil
+	}
+
+	order := []string{}
+	byCommit := map[string]*BlameAttribution{}
+
+	for _, chunk := range fp.Chunks {
+		oldLine := chunk.OldStart
+		for _, l := range chunk.Lines {
+			if l.Op == Delete {
+				if idx := oldLine - 1; idx >= 0 && idx < len(blamed) {
+					bl := blamed[idx]
+					attr, ok := byCommit[bl.Commit]
+					if !ok {
+						attr = &BlameAttribution{Commit: bl.Commit, Subject: bl.Subject, Author: bl.Author, Age: bl.Age}
+						byCommit[bl.Commit] = attr
+						order = append(order, bl.Commit)
+					}
+					attr.Lines++
+				}
+			}
+			if l.Op != Add {
+				oldLine++
+			}
+		}
+	}
+
+	result := make([]BlameAttribution, 0, len(order))
+	for _, sha := range order {
+		result = append(result, *byCommit[sha])
+	}
+	return result
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
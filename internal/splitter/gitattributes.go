@@ -0,0 +1,152 @@
+package splitter
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// attrPattern is one parsed line of a .gitattributes file: a pathspec and
+// the set of attributes it assigns.
+//
+// NOTE: once github.com/go-git/go-git/v5 is vendored this should defer to
+// plumbing/format/gitattributes, which implements the full gitattributes
+// matching semantics (negation, directory-only patterns, macros). This is a
+// deliberately small subset covering the common linguist/diff-driver cases,
+// tracked in `gitmind doctor`'s "Deferred library integrations" section.
+type attrPattern struct {
+	Pattern string
+	Attrs   map[string]string
+}
+
+// loadGitAttributes reads .gitattributes from the current working
+// directory (gitmind, like git itself, is always invoked from inside the
+// repo), returning the parsed patterns in file order. A missing file is not
+// an error: callers simply get no patterns, and every file resolves to the
+// zero attribute set.
+func loadGitAttributes() []attrPattern {
+	data, err := os.ReadFile(".gitattributes")
+	if err != nil {
+		return nil
+	}
+	return parseGitAttributes(data)
+}
+
+func parseGitAttributes(data []byte) []attrPattern {
+	var patterns []attrPattern
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		attrs := make(map[string]string, len(fields)-1)
+		for _, f := range fields[1:] {
+			switch {
+			case strings.HasPrefix(f, "-"):
+				attrs[f[1:]] = "false"
+			case strings.Contains(f, "="):
+				parts := strings.SplitN(f, "=", 2)
+				attrs[parts[0]] = parts[1]
+			default:
+				attrs[f] = "true"
+			}
+		}
+
+		patterns = append(patterns, attrPattern{Pattern: fields[0], Attrs: attrs})
+	}
+
+	return patterns
+}
+
+// matchGitAttributes resolves the attribute set that applies to file,
+// applying patterns in order so a later line overrides an earlier one for
+// the same attribute key (the common case in practice; gitattributes'
+// full precedence rules around directory scoping are not implemented).
+func matchGitAttributes(patterns []attrPattern, file string) map[string]string {
+	resolved := make(map[string]string)
+	base := filepath.Base(file)
+
+	for _, p := range patterns {
+		if !gitAttrPatternMatches(p.Pattern, file, base) {
+			continue
+		}
+		for k, v := range p.Attrs {
+			resolved[k] = v
+		}
+	}
+
+	return resolved
+}
+
+// gitAttrPatternMatches implements the subset of gitattributes globbing
+// used in practice: a bare pattern like "*.pb.go" matches the basename,
+// while a pattern containing a "/" matches against the full path.
+func gitAttrPatternMatches(pattern, file, base string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	target := base
+	if strings.Contains(pattern, "/") {
+		target = file
+	}
+
+	ok, err := filepath.Match(pattern, target)
+	return err == nil && ok
+}
+
+// languageFromAttributes extracts a human-readable language hint from a
+// diff=<driver> attribute (e.g. "diff=golang" -> "Go"), falling back to the
+// file extension when no diff driver is set.
+func languageFromAttributes(attrs map[string]string, file string) string {
+	if driver, ok := attrs["diff"]; ok {
+		if lang, ok := diffDriverLanguages[driver]; ok {
+			return lang
+		}
+	}
+	return languageFromExtension(filepath.Ext(file))
+}
+
+var diffDriverLanguages = map[string]string{
+	"golang":     "Go",
+	"python":     "Python",
+	"javascript": "JavaScript",
+	"typescript": "TypeScript",
+	"rust":       "Rust",
+	"java":       "Java",
+	"cpp":        "C++",
+}
+
+var extensionLanguages = map[string]string{
+	".go":    "Go",
+	".py":    "Python",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".rs":    "Rust",
+	".java":  "Java",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".c":     "C",
+	".cpp":   "C++",
+	".h":     "C",
+	".hpp":   "C++",
+	".cs":    "C#",
+	".kt":    "Kotlin",
+	".swift": "Swift",
+}
+
+func languageFromExtension(ext string) string {
+	if lang, ok := extensionLanguages[strings.ToLower(ext)]; ok {
+		return lang
+	}
+	return ""
+}
@@ -1,426 +1,560 @@
-package splitter
-
-import (
-	"bufio"
-	"fmt"
-	"os"
-	"os/exec"
-	"strings"
-
-	"github.com/Harri200191/gitmind/internal/config"
-)
-
-// MultiCommitManager handles the process of creating multiple commits
-type MultiCommitManager struct {
-	config   config.Config
-	splitter *Splitter
-}
-
-// CommitProposal represents a proposed commit
-type CommitProposal struct {
-	Files   []string `json:"files"`
-	Message string   `json:"message"`
-	Changes []Change `json:"changes"`
-}
-
-// NewMultiCommitManager creates a new multi-commit manager
-func NewMultiCommitManager(cfg config.Config) *MultiCommitManager {
-	return &MultiCommitManager{
-		config:   cfg,
-		splitter: New(cfg),
-	}
-}
-
-// ProcessStagedChanges analyzes staged changes and proposes multiple commits
-func (mcm *MultiCommitManager) ProcessStagedChanges() ([]CommitProposal, error) {
-	if !mcm.config.MultiCommit.Enabled {
-		return nil, nil
-	}
-
-	// Get the staged diff
-	diff, err := mcm.getStagedDiff()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get staged diff: %v", err)
-	}
-
-	if strings.TrimSpace(diff) == "" {
-		return nil, nil
-	}
-
-	// Analyze the diff for logical changes
-	changes, err := mcm.splitter.AnalyzeDiff(diff)
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze diff: %v", err)
-	}
-
-	// Cluster related changes
-	clusters, err := mcm.splitter.ClusterChanges(changes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to cluster changes: %v", err)
-	}
-
-	// Generate commit proposals
-	var proposals []CommitProposal
-	for i, cluster := range clusters {
-		proposal := CommitProposal{
-			Files:   mcm.extractFilesFromCluster(cluster),
-			Message: mcm.generateCommitMessage(cluster, i+1, len(clusters)),
-			Changes: cluster.Changes,
-		}
-		proposals = append(proposals, proposal)
-	}
-
-	return proposals, nil
-}
-
-// ExecuteMultiCommit creates multiple commits based on proposals
-func (mcm *MultiCommitManager) ExecuteMultiCommit(proposals []CommitProposal) error {
-	if len(proposals) <= 1 {
-		// If only one proposal, let normal commit process handle it
-		return nil
-	}
-
-	// Prompt user for confirmation if enabled
-	if mcm.config.MultiCommit.PromptUser {
-		confirmed, err := mcm.promptUserForConfirmation(proposals)
-		if err != nil {
-			return err
-		}
-		if !confirmed {
-			fmt.Println("Multi-commit cancelled by user")
-			return nil
-		}
-	}
-
-	// Store the current staging area
-	if err := mcm.stashCurrentChanges(); err != nil {
-		return fmt.Errorf("failed to stash changes: %v", err)
-	}
-
-	// Create each commit
-	for i, proposal := range proposals {
-		if err := mcm.createCommit(proposal, i+1, len(proposals)); err != nil {
-			// If any commit fails, try to restore the staging area
-			mcm.restoreChanges()
-			return fmt.Errorf("failed to create commit %d: %v", i+1, err)
-		}
-	}
-
-	fmt.Printf("Successfully created %d commits\n", len(proposals))
-	return nil
-}
-
-// getStagedDiff retrieves the current staged diff
-func (mcm *MultiCommitManager) getStagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return string(output), nil
-}
-
-// extractFilesFromCluster gets all unique files from a cluster
-func (mcm *MultiCommitManager) extractFilesFromCluster(cluster Cluster) []string {
-	fileMap := make(map[string]bool)
-
-	for _, change := range cluster.Changes {
-		for _, file := range change.Files {
-			fileMap[file] = true
-		}
-	}
-
-	var files []string
-	for file := range fileMap {
-		files = append(files, file)
-	}
-
-	return files
-}
-
-// generateCommitMessage creates a commit message for a cluster
-func (mcm *MultiCommitManager) generateCommitMessage(cluster Cluster, index, total int) string {
-	baseMessage := cluster.Description
-
-	if total > 1 {
-		// Add context about this being part of a multi-commit series
-		baseMessage = fmt.Sprintf("%s (%d/%d)", baseMessage, index, total)
-	}
-
-	// Add details about the changes
-	if len(cluster.Changes) == 1 {
-		change := cluster.Changes[0]
-		if len(change.Functions) > 0 {
-			baseMessage += fmt.Sprintf("\n\nModified functions: %s", strings.Join(change.Functions, ", "))
-		}
-	} else {
-		// Multiple changes in this commit
-		var allFunctions []string
-		for _, change := range cluster.Changes {
-			allFunctions = append(allFunctions, change.Functions...)
-		}
-		if len(allFunctions) > 0 {
-			baseMessage += fmt.Sprintf("\n\nModified functions: %s", strings.Join(unique(allFunctions), ", "))
-		}
-	}
-
-	return baseMessage
-}
-
-// promptUserForConfirmation asks user to confirm the multi-commit proposal
-func (mcm *MultiCommitManager) promptUserForConfirmation(proposals []CommitProposal) (bool, error) {
-	fmt.Printf("\nüîç Multi-commit proposal detected %d logical changes:\n\n", len(proposals))
-
-	for i, proposal := range proposals {
-		fmt.Printf("Commit %d: %s\n", i+1, proposal.Message)
-		fmt.Printf("  Files: %s\n", strings.Join(proposal.Files, ", "))
-		fmt.Println()
-	}
-
-	fmt.Print("Do you want to proceed with multi-commit? [Y/n]: ")
-
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false, err
-	}
-
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "" || response == "y" || response == "yes", nil
-}
-
-// stashCurrentChanges temporarily stores the current staging area
-func (mcm *MultiCommitManager) stashCurrentChanges() error {
-	// First, check if we have any staged changes
-	cmd := exec.Command("git", "diff", "--cached", "--quiet")
-	if err := cmd.Run(); err == nil {
-		// No staged changes, nothing to stash
-		return nil
-	}
-
-	// Try to stash staged changes only first
-	cmd = exec.Command("git", "stash", "push", "--staged", "--message", "gitmind-multi-commit-temp")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		// If stash fails, try alternative approach: save index to temporary branch
-		fmt.Printf("Stash failed, using temporary branch approach: %s\n", string(output))
-		return mcm.stashUsingTempBranch()
-	}
-
-	fmt.Printf("Stashed changes successfully\n")
-	return nil
-}
-
-// stashUsingTempBranch creates a temporary commit to save current state
-func (mcm *MultiCommitManager) stashUsingTempBranch() error {
-	// Create a temporary commit on current branch to save staged changes
-	cmd := exec.Command("git", "commit", "-m", "gitmind: temporary commit for multi-commit splitting (will be reset)")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create temp commit: %v", err)
-	}
-
-	// Store the commit hash for later
-	cmd = exec.Command("git", "rev-parse", "HEAD")
-	tempCommitHash, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("failed to get temp commit hash: %v", err)
-	}
-
-	// Reset to previous commit to clear staging area
-	cmd = exec.Command("git", "reset", "--soft", "HEAD~1")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to reset to clear staging: %v", err)
-	}
-
-	// Store temp commit hash for restoration
-	// We'll use git notes to store this metadata
-	tempCommitHashStr := strings.TrimSpace(string(tempCommitHash))
-	cmd = exec.Command("git", "notes", "add", "-m", "gitmind-temp-commit:"+tempCommitHashStr, "HEAD")
-	cmd.Run() // Ignore errors
-
-	return nil
-}
-
-// restoreChanges restores the staging area from stash or temp commit
-func (mcm *MultiCommitManager) restoreChanges() error {
-	// First try to pop stash if it exists
-	cmd := exec.Command("git", "stash", "list")
-	output, err := cmd.Output()
-	if err == nil && strings.Contains(string(output), "gitmind-multi-commit-temp") {
-		// Stash exists, pop it
-		cmd = exec.Command("git", "stash", "pop")
-		return cmd.Run()
-	}
-
-	// Check if we have temp commit info in git notes
-	cmd = exec.Command("git", "notes", "show", "HEAD")
-	notesOutput, err := cmd.Output()
-	if err == nil && strings.Contains(string(notesOutput), "gitmind-temp-commit:") {
-		return mcm.restoreFromTempBranch()
-	}
-
-	// Nothing to restore
-	return nil
-}
-
-// restoreFromTempBranch restores changes from temporary commit using git notes
-func (mcm *MultiCommitManager) restoreFromTempBranch() error {
-	// Check if we have stored temp commit hash in notes
-	cmd := exec.Command("git", "notes", "show", "HEAD")
-	notesOutput, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("no temp commit found in notes: %v", err)
-	}
-
-	notesStr := strings.TrimSpace(string(notesOutput))
-	if !strings.HasPrefix(notesStr, "gitmind-temp-commit:") {
-		return fmt.Errorf("invalid temp commit note format")
-	}
-
-	tempCommitHash := strings.TrimPrefix(notesStr, "gitmind-temp-commit:")
-
-	// Use git cherry-pick to restore the temp commit changes to staging
-	cmd = exec.Command("git", "cherry-pick", "-n", tempCommitHash)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to cherry-pick temp commit: %v", err)
-	}
-
-	// Clean up the note
-	cmd = exec.Command("git", "notes", "remove", "HEAD")
-	cmd.Run() // Ignore errors
-
-	return nil
-}
-
-// createCommit creates a single commit for the given proposal
-func (mcm *MultiCommitManager) createCommit(proposal CommitProposal, index, total int) error {
-	// First, restore all changes to staging area
-	if err := mcm.restoreChanges(); err != nil {
-		return fmt.Errorf("failed to restore changes: %v", err)
-	}
-
-	// Reset staging area to clean state
-	cmd := exec.Command("git", "reset")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to reset staging area: %v", err)
-	}
-
-	// Stage only the files for this commit
-	for _, file := range proposal.Files {
-		if err := mcm.stageFile(file); err != nil {
-			// If staging fails (file might be deleted), try to handle it gracefully
-			fmt.Printf("Warning: failed to stage file %s: %v\n", file, err)
-			continue
-		}
-	}
-
-	// Check if we have anything staged
-	cmd = exec.Command("git", "diff", "--cached", "--quiet")
-	if err := cmd.Run(); err == nil {
-		fmt.Printf("Warning: No changes staged for commit %d/%d, skipping\n", index, total)
-		return nil
-	}
-
-	// Create the commit
-	cmd = exec.Command("git", "commit", "-m", proposal.Message)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("git commit failed: %v", err)
-	}
-
-	fmt.Printf("‚úì Created commit %d/%d: %s\n", index, total, proposal.Message)
-
-	// For subsequent commits, we need to stash the remaining changes again
-	if index < total {
-		if err := mcm.stashCurrentChanges(); err != nil {
-			return fmt.Errorf("failed to stash remaining changes: %v", err)
-		}
-	}
-
-	return nil
-}
-
-// stageFile stages a specific file with error handling
-func (mcm *MultiCommitManager) stageFile(file string) error {
-	// Check if file exists before trying to stage
-	if _, err := os.Stat(file); os.IsNotExist(err) {
-		// File doesn't exist, try to stage as deleted
-		cmd := exec.Command("git", "rm", file)
-		if err := cmd.Run(); err != nil {
-			// If rm fails, the file might already be tracked as deleted
-			return fmt.Errorf("file %s not found and cannot be removed: %v", file, err)
-		}
-		return nil
-	}
-
-	// File exists, stage normally
-	cmd := exec.Command("git", "add", file)
-	return cmd.Run()
-}
-
-// stageFilePartial stages only specific hunks of a file
-// This is a simplified version - real implementation would need more sophisticated hunk selection
-func (mcm *MultiCommitManager) stageFilePartial(file string, hunks []Hunk) error {
-	// For now, stage the entire file
-	// TODO: Implement selective staging of hunks using git add --patch or similar
-	return mcm.stageFile(file)
-}
-
-// InteractiveMultiCommit provides an interactive mode for multi-commit creation
-func (mcm *MultiCommitManager) InteractiveMultiCommit() error {
-	proposals, err := mcm.ProcessStagedChanges()
-	if err != nil {
-		return err
-	}
-
-	if len(proposals) <= 1 {
-		fmt.Println("No multi-commit opportunities detected")
-		return nil
-	}
-
-	fmt.Printf("\nüéØ Detected %d logical changes that can be split into separate commits\n", len(proposals))
-
-	// Show proposals with options to modify
-	for {
-		fmt.Println("\nCommit proposals:")
-		for i, proposal := range proposals {
-			fmt.Printf("  %d. %s\n", i+1, proposal.Message)
-			fmt.Printf("     Files: %s\n", strings.Join(proposal.Files, ", "))
-		}
-
-		fmt.Println("\nOptions:")
-		fmt.Println("  1. Accept all proposals")
-		fmt.Println("  2. Edit proposals")
-		fmt.Println("  3. Cancel")
-		fmt.Print("\nChoice [1]: ")
-
-		reader := bufio.NewReader(os.Stdin)
-		choice, _ := reader.ReadString('\n')
-		choice = strings.TrimSpace(choice)
-
-		if choice == "" || choice == "1" {
-			return mcm.ExecuteMultiCommit(proposals)
-		} else if choice == "2" {
-			// TODO: Implement proposal editing
-			fmt.Println("Proposal editing not yet implemented")
-			continue
-		} else if choice == "3" {
-			fmt.Println("Multi-commit cancelled")
-			return nil
-		} else {
-			fmt.Println("Invalid choice, please try again")
-		}
-	}
-}
-
-// Helper function (already exists in splitter.go, but added here for completeness)
-func unique(items []string) []string {
-	keys := make(map[string]bool)
-	var result []string
-	for _, item := range items {
-		if !keys[item] {
-			keys[item] = true
-			result = append(result, item)
-		}
-	}
-	return result
-}
+package splitter
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/gitx"
+	"github.com/Harri200191/gitmind/internal/patch"
+)
+
+// newRepo builds the Repo a MultiCommitManager talks to. It's a package
+// var, like goAnalyzer in splitter.go, so a test can swap in an in-memory
+// fake instead of shelling out to a real git repo.
+var newRepo = gitx.New
+
+// MultiCommitManager handles the process of creating multiple commits
+type MultiCommitManager struct {
+	config   config.Config
+	splitter *Splitter
+	repo     gitx.Repo
+
+	// fileHunks is every hunk ProcessStagedChanges found for each file, in
+	// diff order, captured once so createCommit can tell a proposal's
+	// hunks for a file apart from that file's complete hunk set -- a
+	// strict subset means stageFilePartial, not stageFile.
+	fileHunks map[string][]Hunk
+	// appliedHunks tracks, per file, which of fileHunks' hunks an earlier
+	// createCommit call in the current ExecuteMultiCommit run has already
+	// staged, so BuildPartialPatch can re-address a later commit's hunks
+	// against HEAD's current state instead of the original pre-image.
+	appliedHunks map[string][]Hunk
+	// backup is the ref ExecuteMultiCommit pointed at the index's state
+	// before its first commit, restored before every subsequent one.
+	backup gitx.Backup
+
+	// Editor, if set, drives InteractiveMultiCommit's "Edit proposals"
+	// choice: given the current proposals it returns the reshaped ones to
+	// execute, or nil if the user cancelled. Left unset by
+	// NewMultiCommitManager since the natural implementation --
+	// ui.NewProposalEditor -- imports this package for CommitProposal and
+	// Hunk, so the caller wires it in (see cmd/gitmind) rather than
+	// splitter importing ui back.
+	Editor func([]CommitProposal) ([]CommitProposal, error)
+
+	// MessageGenerator, if set, lets generateCommitMessage ask an LLM
+	// provider for a cluster's subject/body instead of the built-in
+	// "Modified functions: X, Y" summary, given a synthesized diff of
+	// just that cluster's hunks. Left unset by NewMultiCommitManager for
+	// the same import-cycle reason as Editor: the natural implementation
+	// is llm.Generate, and internal/llm already imports this package to
+	// build its own prompts.
+	MessageGenerator func(diff string) (string, error)
+}
+
+// CommitProposal represents a proposed commit
+type CommitProposal struct {
+	Files   []string `json:"files"`
+	Message string   `json:"message"`
+	Changes []Change `json:"changes"`
+
+	// LinePatch, if set, is a synthetic diff built by internal/patch for a
+	// proposal RefineLines has narrowed below whole-hunk granularity.
+	// createCommit stages it directly via Repo.StageHunks instead of its
+	// usual whole-file/whole-hunk logic.
+	LinePatch string `json:"-"`
+}
+
+// NewMultiCommitManager creates a new multi-commit manager
+func NewMultiCommitManager(cfg config.Config) *MultiCommitManager {
+	return &MultiCommitManager{
+		config:   cfg,
+		splitter: New(cfg),
+		repo:     newRepo(),
+	}
+}
+
+// ProcessStagedChanges analyzes staged changes and proposes multiple commits
+func (mcm *MultiCommitManager) ProcessStagedChanges() ([]CommitProposal, error) {
+	if !mcm.config.MultiCommit.Enabled {
+		return nil, nil
+	}
+
+	// Get the staged diff
+	diff, err := mcm.repo.StagedDiff()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged diff: %v", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		return nil, nil
+	}
+
+	// Analyze the diff for logical changes
+	changes, err := mcm.splitter.AnalyzeDiff(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze diff: %v", err)
+	}
+
+	mcm.fileHunks = make(map[string][]Hunk, len(changes))
+	for _, change := range changes {
+		for _, file := range change.Files {
+			mcm.fileHunks[file] = change.Hunks
+		}
+	}
+
+	// Cluster related changes
+	clusters, err := mcm.splitter.ClusterChanges(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cluster changes: %v", err)
+	}
+
+	// Generate commit proposals
+	var proposals []CommitProposal
+	for i, cluster := range clusters {
+		proposal := CommitProposal{
+			Files:   mcm.extractFilesFromCluster(cluster),
+			Message: mcm.generateCommitMessage(cluster, i+1, len(clusters)),
+			Changes: cluster.Changes,
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	return proposals, nil
+}
+
+// ExecuteMultiCommit creates multiple commits based on proposals
+func (mcm *MultiCommitManager) ExecuteMultiCommit(proposals []CommitProposal) error {
+	if len(proposals) <= 1 {
+		// If only one proposal, let normal commit process handle it
+		return nil
+	}
+
+	// Prompt user for confirmation if enabled
+	if mcm.config.MultiCommit.PromptUser {
+		confirmed, err := mcm.promptUserForConfirmation(proposals)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Multi-commit cancelled by user")
+			return nil
+		}
+	}
+
+	// Back up the current index to a ref before reshaping it commit by
+	// commit, so a process death mid-split still leaves something
+	// `gitmind multi-commit --abort` can find and restore.
+	backup, err := mcm.repo.Backup()
+	if err != nil {
+		return fmt.Errorf("failed to back up staged changes: %v", err)
+	}
+	mcm.backup = backup
+	mcm.appliedHunks = make(map[string][]Hunk)
+
+	// Create each commit
+	for i, proposal := range proposals {
+		if err := mcm.createCommit(proposal, i+1, len(proposals)); err != nil {
+			// If any commit fails, try to restore from the backup
+			mcm.repo.RestoreBackup(mcm.backup)
+			mcm.repo.DeleteBackup(mcm.backup)
+			return fmt.Errorf("failed to create commit %d: %v", i+1, err)
+		}
+	}
+
+	if err := mcm.repo.DeleteBackup(mcm.backup); err != nil {
+		fmt.Printf("Warning: failed to delete backup ref: %v\n", err)
+	}
+	fmt.Printf("Successfully created %d commits\n", len(proposals))
+	return nil
+}
+
+// Abort restores the repository from the most recent multi-commit backup
+// ref -- left behind by an ExecuteMultiCommit run that failed or whose
+// process died before it could clean up after itself -- and deletes the
+// ref once restored.
+func (mcm *MultiCommitManager) Abort() error {
+	backup, found, err := mcm.repo.FindLatestBackup()
+	if err != nil {
+		return fmt.Errorf("failed to look for a backup ref: %v", err)
+	}
+	if !found {
+		fmt.Println("No multi-commit backup to restore")
+		return nil
+	}
+	if err := mcm.repo.RestoreBackup(backup); err != nil {
+		return fmt.Errorf("failed to restore backup %s: %v", backup.Ref, err)
+	}
+	if err := mcm.repo.DeleteBackup(backup); err != nil {
+		return fmt.Errorf("failed to delete backup ref %s: %v", backup.Ref, err)
+	}
+	fmt.Printf("Restored from backup %s\n", backup.Ref)
+	return nil
+}
+
+// ClusterDiff renders a standalone unified diff covering every hunk in
+// cluster, via the same BuildPartialPatch stageFilePartial uses to
+// synthesize a commit's patch -- giving generateCommitMessage's
+// MessageGenerator (and any other caller wanting a per-cluster diff, such
+// as cmd/gitmind's `summarize`) real diff text to work from instead of a
+// pre-summarized function list. Built directly from AnalyzeDiff's
+// original hunks, with no applied hunks and none remaining, since this
+// runs before any commit in the split has touched the repository.
+func ClusterDiff(cluster Cluster) string {
+	var b strings.Builder
+	for _, change := range cluster.Changes {
+		if len(change.Hunks) == 0 {
+			continue
+		}
+		b.WriteString(BuildPartialPatch(change.Hunks[0].File, change.Hunks, nil, 0))
+	}
+	return b.String()
+}
+
+// hunksForFile returns the hunks changes recorded for file -- nil if
+// changes has no Change touching it.
+func hunksForFile(changes []Change, file string) []Hunk {
+	for _, change := range changes {
+		for _, f := range change.Files {
+			if f == file {
+				return change.Hunks
+			}
+		}
+	}
+	return nil
+}
+
+// extractFilesFromCluster gets all unique files from a cluster
+func (mcm *MultiCommitManager) extractFilesFromCluster(cluster Cluster) []string {
+	fileMap := make(map[string]bool)
+
+	for _, change := range cluster.Changes {
+		for _, file := range change.Files {
+			fileMap[file] = true
+		}
+	}
+
+	var files []string
+	for file := range fileMap {
+		files = append(files, file)
+	}
+
+	return files
+}
+
+// generateCommitMessage creates a commit message for a cluster, asking
+// mcm.MessageGenerator (when set) to write one from the cluster's own
+// hunks before falling back to the built-in "Modified functions: X, Y"
+// summary -- on a generator error just as much as when it's unset, since
+// a cluster without a commit message is worse than one with a dumber one.
+func (mcm *MultiCommitManager) generateCommitMessage(cluster Cluster, index, total int) string {
+	if mcm.MessageGenerator != nil {
+		if message, err := mcm.MessageGenerator(ClusterDiff(cluster)); err == nil && strings.TrimSpace(message) != "" {
+			if total > 1 {
+				return fmt.Sprintf("%s (%d/%d)", message, index, total)
+			}
+			return message
+		}
+	}
+
+	baseMessage := cluster.Description
+
+	if total > 1 {
+		// Add context about this being part of a multi-commit series
+		baseMessage = fmt.Sprintf("%s (%d/%d)", baseMessage, index, total)
+	}
+
+	// Add details about the changes
+	if len(cluster.Changes) == 1 {
+		change := cluster.Changes[0]
+		if len(change.Functions) > 0 {
+			baseMessage += fmt.Sprintf("\n\nModified functions: %s", strings.Join(change.Functions, ", "))
+		}
+	} else {
+		// Multiple changes in this commit
+		var allFunctions []string
+		for _, change := range cluster.Changes {
+			allFunctions = append(allFunctions, change.Functions...)
+		}
+		if len(allFunctions) > 0 {
+			baseMessage += fmt.Sprintf("\n\nModified functions: %s", strings.Join(unique(allFunctions), ", "))
+		}
+	}
+
+	return baseMessage
+}
+
+// promptUserForConfirmation asks user to confirm the multi-commit proposal
+func (mcm *MultiCommitManager) promptUserForConfirmation(proposals []CommitProposal) (bool, error) {
+	fmt.Printf("\nüîç Multi-commit proposal detected %d logical changes:\n\n", len(proposals))
+
+	for i, proposal := range proposals {
+		fmt.Printf("Commit %d: %s\n", i+1, proposal.Message)
+		fmt.Printf("  Files: %s\n", strings.Join(proposal.Files, ", "))
+		fmt.Println()
+	}
+
+	fmt.Print("Do you want to proceed with multi-commit? [Y/n]: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "" || response == "y" || response == "yes", nil
+}
+
+// createCommit creates a single commit for the given proposal
+func (mcm *MultiCommitManager) createCommit(proposal CommitProposal, index, total int) error {
+	// Restore HEAD and the index from the backup, then unstage everything
+	// so only this proposal's files/hunks get re-added below.
+	if err := mcm.repo.RestoreBackup(mcm.backup); err != nil {
+		return fmt.Errorf("failed to restore from backup: %v", err)
+	}
+	if err := mcm.repo.Reset(); err != nil {
+		return fmt.Errorf("failed to reset staging area: %v", err)
+	}
+
+	// A proposal RefineLines has narrowed below whole-hunk granularity
+	// carries its own synthetic diff; stage exactly that and skip the
+	// whole-file/whole-hunk logic below.
+	if proposal.LinePatch != "" {
+		if err := mcm.repo.StageHunks(proposal.LinePatch); err != nil {
+			return fmt.Errorf("failed to stage refined lines: %v", err)
+		}
+		for _, file := range proposal.Files {
+			mcm.appliedHunks[file] = append(mcm.appliedHunks[file], hunksForFile(proposal.Changes, file)...)
+		}
+	} else {
+		// Stage only the files for this commit. A file whose Change in this
+		// proposal carries every hunk the diff found for it is staged whole;
+		// one carrying a strict subset is staged hunk-by-hunk via
+		// stageFilePartial so the rest of the file's changes stay uncommitted
+		// for a later proposal.
+		for _, file := range proposal.Files {
+			hunks := hunksForFile(proposal.Changes, file)
+			if len(hunks) > 0 && len(hunks) < len(mcm.fileHunks[file]) {
+				if err := mcm.stageFilePartial(file, hunks); err != nil {
+					return fmt.Errorf("failed to partially stage %s: %v", file, err)
+				}
+				mcm.appliedHunks[file] = append(mcm.appliedHunks[file], hunks...)
+				continue
+			}
+			if err := mcm.repo.StageFiles([]string{file}); err != nil {
+				// If staging fails (file might be deleted), try to handle it gracefully
+				fmt.Printf("Warning: failed to stage file %s: %v\n", file, err)
+				continue
+			}
+			mcm.appliedHunks[file] = append(mcm.appliedHunks[file], hunks...)
+		}
+	}
+
+	// Check if we have anything staged
+	if diff, err := mcm.repo.StagedDiff(); err == nil && strings.TrimSpace(diff) == "" {
+		fmt.Printf("Warning: No changes staged for commit %d/%d, skipping\n", index, total)
+		return nil
+	}
+
+	// Create the commit
+	if _, err := mcm.repo.Commit(proposal.Message); err != nil {
+		return fmt.Errorf("git commit failed: %v", err)
+	}
+
+	fmt.Printf("‚úì Created commit %d/%d: %s\n", index, total, proposal.Message)
+	return nil
+}
+
+// stageFilePartial stages only hunks of file -- a strict subset of the
+// file's full hunk set -- by synthesizing a minimal unified diff
+// containing just those hunks (BuildPartialPatch) and feeding it to
+// Repo.StageHunks. Hunks already staged for this file by an earlier
+// createCommit call in the current run are looked up from
+// mcm.appliedHunks, so the synthesized patch's headers stay addressed
+// correctly against HEAD's current state.
+func (mcm *MultiCommitManager) stageFilePartial(file string, hunks []Hunk) error {
+	total := mcm.fileHunks[file]
+	applied := mcm.appliedHunks[file]
+	remaining := len(total) - len(applied) - len(hunks)
+
+	patch := BuildPartialPatch(file, hunks, applied, remaining)
+	return mcm.repo.StageHunks(patch)
+}
+
+// RefineLines narrows proposal below whole-hunk granularity: for each
+// hunk of each of proposal.Files, it asks which added/removed lines to
+// keep (blank keeps the whole hunk), then renders the kept lines into a
+// synthetic diff via internal/patch and returns proposal with LinePatch
+// set to it. Deselected lines aren't dropped from the commit entirely --
+// they simply stay staged for a later proposal, same as a whole deselected
+// hunk would.
+func (mcm *MultiCommitManager) RefineLines(proposal CommitProposal) (CommitProposal, error) {
+	diff, err := mcm.repo.StagedDiff()
+	if err != nil {
+		return proposal, fmt.Errorf("failed to get staged diff: %v", err)
+	}
+
+	mgr, err := patch.NewManager(diff)
+	if err != nil {
+		return proposal, fmt.Errorf("failed to parse staged diff: %v", err)
+	}
+
+	wanted := make(map[string]bool, len(proposal.Files))
+	for _, file := range proposal.Files {
+		wanted[file] = true
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, fd := range mgr.Patch().Files {
+		if !wanted[fd.File()] {
+			continue
+		}
+		for hi, hunk := range fd.Hunks {
+			fmt.Printf("\n%s %s\n", fd.File(), hunk.Header)
+			for li, line := range hunk.Lines {
+				if line.Op == patch.Context {
+					continue
+				}
+				fmt.Printf("  [%d] %s%s\n", li, line.Op.String(), indentPrefix(line))
+			}
+			fmt.Print("Keep which lines (blank = whole hunk): ")
+			answer, _ := reader.ReadString('\n')
+			answer = strings.TrimSpace(answer)
+			if answer == "" {
+				mgr.Select(patch.Selection{File: fd.File(), HunkIndex: hi})
+				continue
+			}
+			kept := make(map[int]bool)
+			for _, field := range strings.Split(answer, ",") {
+				field = strings.TrimSpace(field)
+				if field == "" {
+					continue
+				}
+				var idx int
+				if _, err := fmt.Sscanf(field, "%d", &idx); err == nil {
+					kept[idx] = true
+				}
+			}
+			mgr.Select(patch.Selection{File: fd.File(), HunkIndex: hi, Lines: kept})
+		}
+	}
+
+	rendered, err := mgr.Render()
+	if err != nil {
+		return proposal, fmt.Errorf("failed to render refined patch: %v", err)
+	}
+	proposal.LinePatch = rendered
+	return proposal, nil
+}
+
+// indentPrefix renders a patch.Line's text with a single leading space so
+// it lines up under its "added"/"removed" tag instead of running on.
+func indentPrefix(line patch.Line) string {
+	return ": " + line.Text
+}
+
+// InteractiveMultiCommit provides an interactive mode for multi-commit creation
+func (mcm *MultiCommitManager) InteractiveMultiCommit() error {
+	proposals, err := mcm.ProcessStagedChanges()
+	if err != nil {
+		return err
+	}
+
+	if len(proposals) <= 1 {
+		fmt.Println("No multi-commit opportunities detected")
+		return nil
+	}
+
+	fmt.Printf("\nüéØ Detected %d logical changes that can be split into separate commits\n", len(proposals))
+
+	// Show proposals with options to modify
+	for {
+		fmt.Println("\nCommit proposals:")
+		for i, proposal := range proposals {
+			fmt.Printf("  %d. %s\n", i+1, proposal.Message)
+			fmt.Printf("     Files: %s\n", strings.Join(proposal.Files, ", "))
+		}
+
+		fmt.Println("\nOptions:")
+		fmt.Println("  1. Accept all proposals")
+		fmt.Println("  2. Edit proposals")
+		fmt.Println("  3. Cancel")
+		fmt.Println("  4. Refine a proposal to line granularity")
+		fmt.Print("\nChoice [1]: ")
+
+		reader := bufio.NewReader(os.Stdin)
+		choice, _ := reader.ReadString('\n')
+		choice = strings.TrimSpace(choice)
+
+		if choice == "" || choice == "1" {
+			return mcm.ExecuteMultiCommit(proposals)
+		} else if choice == "2" {
+			if mcm.Editor == nil {
+				fmt.Println("Proposal editing not available")
+				continue
+			}
+			edited, err := mcm.Editor(proposals)
+			if err != nil {
+				return fmt.Errorf("failed to edit proposals: %v", err)
+			}
+			if edited == nil {
+				fmt.Println("Multi-commit cancelled")
+				return nil
+			}
+			return mcm.ExecuteMultiCommit(edited)
+		} else if choice == "3" {
+			fmt.Println("Multi-commit cancelled")
+			return nil
+		} else if choice == "4" {
+			idx, err := readProposalIndex(len(proposals))
+			if err != nil {
+				fmt.Printf("Invalid proposal: %v\n", err)
+				continue
+			}
+			refined, err := mcm.RefineLines(proposals[idx])
+			if err != nil {
+				fmt.Printf("Failed to refine proposal: %v\n", err)
+				continue
+			}
+			proposals[idx] = refined
+		} else {
+			fmt.Println("Invalid choice, please try again")
+		}
+	}
+}
+
+// readProposalIndex prompts for and parses a 1-based proposal number,
+// returning its 0-based index.
+func readProposalIndex(count int) (int, error) {
+	fmt.Printf("Which proposal (1-%d)? ", count)
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if _, err := fmt.Sscanf(strings.TrimSpace(line), "%d", &n); err != nil {
+		return 0, fmt.Errorf("invalid index %q", line)
+	}
+	if n < 1 || n > count {
+		return 0, fmt.Errorf("index %d out of range [1,%d]", n, count)
+	}
+	return n - 1, nil
+}
+
+// Helper function (already exists in splitter.go, but added here for completeness)
+func unique(items []string) []string {
+	keys := make(map[string]bool)
+	var result []string
+	for _, item := range items {
+		if !keys[item] {
+			keys[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package splitter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Harri200191/gitmind/internal/config"
+	"github.com/Harri200191/gitmind/internal/gitx"
+)
+
+// fakeRepo is an in-memory gitx.Repo, standing in for the real go-git
+// backed implementation BuildPartialPatch's doc comment describes -- it
+// lets createCommit's staging/committing sequence be exercised without a
+// real git repo on disk.
+type fakeRepo struct {
+	diff      string
+	commits   []string
+	reset     int
+	restored  []gitx.Backup
+	deleted   []gitx.Backup
+	backupSeq int
+}
+
+func (f *fakeRepo) StagedDiff() (string, error) { return f.diff, nil }
+
+func (f *fakeRepo) Backup() (gitx.Backup, error) {
+	f.backupSeq++
+	return gitx.Backup{Ref: fmt.Sprintf("refs/gitmind/backup/%d", f.backupSeq)}, nil
+}
+
+func (f *fakeRepo) RestoreBackup(backup gitx.Backup) error {
+	f.restored = append(f.restored, backup)
+	return nil
+}
+
+func (f *fakeRepo) DeleteBackup(backup gitx.Backup) error {
+	f.deleted = append(f.deleted, backup)
+	return nil
+}
+
+func (f *fakeRepo) FindLatestBackup() (gitx.Backup, bool, error) {
+	if f.backupSeq == 0 {
+		return gitx.Backup{}, false, nil
+	}
+	return gitx.Backup{Ref: fmt.Sprintf("refs/gitmind/backup/%d", f.backupSeq)}, true, nil
+}
+
+func (f *fakeRepo) Reset() error { f.reset++; return nil }
+
+func (f *fakeRepo) StageFiles(files []string) error { return nil }
+
+func (f *fakeRepo) StageHunks(patch string) error { return nil }
+
+func (f *fakeRepo) UnstageHunks(patch string) error { return nil }
+
+func (f *fakeRepo) Commit(message string) (gitx.Hash, error) {
+	f.commits = append(f.commits, message)
+	return gitx.Hash("deadbeef"), nil
+}
+
+func TestExecuteMultiCommitCommitsEachProposal(t *testing.T) {
+	fake := &fakeRepo{}
+	prevNewRepo := newRepo
+	newRepo = func() gitx.Repo { return fake }
+	defer func() { newRepo = prevNewRepo }()
+
+	mcm := NewMultiCommitManager(config.Config{})
+	mcm.fileHunks = map[string][]Hunk{"a.go": nil}
+
+	proposals := []CommitProposal{
+		{Files: []string{"a.go"}, Message: "first"},
+		{Files: []string{"a.go"}, Message: "second"},
+	}
+
+	// Each createCommit call reports nothing staged unless diff is
+	// non-empty, so give the fake something to see after "staging".
+	fake.diff = "diff --git a/a.go b/a.go\n"
+
+	if err := mcm.ExecuteMultiCommit(proposals); err != nil {
+		t.Fatalf("ExecuteMultiCommit failed: %v", err)
+	}
+
+	if len(fake.commits) != 2 || fake.commits[0] != "first" || fake.commits[1] != "second" {
+		t.Fatalf("expected commits [first second], got %v", fake.commits)
+	}
+	if fake.reset != 2 {
+		t.Errorf("expected Reset once per proposal (2), got %d", fake.reset)
+	}
+	if len(fake.restored) != 2 {
+		t.Errorf("expected RestoreBackup once per proposal (2), got %d", len(fake.restored))
+	}
+	if len(fake.deleted) != 1 {
+		t.Errorf("expected the backup ref deleted once on success, got %d", len(fake.deleted))
+	}
+}
+
+func TestExecuteMultiCommitSkipsWhenNothingStaged(t *testing.T) {
+	fake := &fakeRepo{}
+	prevNewRepo := newRepo
+	newRepo = func() gitx.Repo { return fake }
+	defer func() { newRepo = prevNewRepo }()
+
+	mcm := NewMultiCommitManager(config.Config{})
+	mcm.fileHunks = map[string][]Hunk{"a.go": nil}
+
+	// StagedDiff reporting empty after staging means createCommit treats
+	// the proposal as a no-op rather than committing nothing.
+	fake.diff = ""
+	proposals := []CommitProposal{
+		{Files: []string{"a.go"}, Message: "only"},
+		{Files: []string{"a.go"}, Message: "second"},
+	}
+	if err := mcm.ExecuteMultiCommit(proposals); err != nil {
+		t.Fatalf("ExecuteMultiCommit failed: %v", err)
+	}
+	if len(fake.commits) != 0 {
+		t.Errorf("expected no commits when nothing is staged, got %v", fake.commits)
+	}
+}
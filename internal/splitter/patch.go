@@ -0,0 +1,317 @@
+package splitter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operation identifies the kind of a single diff chunk line, mirroring
+// go-git's plumbing/format/diff.Operation (Equal/Add/Delete).
+//
+// NOTE: once github.com/go-git/go-git/v5 is vendored, this parser should be
+// replaced by feeding plumbing/object.Patch (or plumbing/format/diff.Patch)
+// directly so renames/copies/binary metadata come from git itself instead
+// of being reconstructed from unified-diff text. Until then this hand-rolled
+// parser is a faithful stand-in for that shape -- `gitmind doctor`'s
+// "Deferred library integrations" section says so out loud too, so this
+// isn't only discoverable by reading the source.
+type Operation int
+
+const (
+	Equal Operation = iota
+	Add
+	Delete
+)
+
+func (o Operation) String() string {
+	switch o {
+	case Add:
+		return "add"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// Line is a single line within a Chunk, tagged with its Operation.
+type Line struct {
+	Op   Operation
+	Text string
+}
+
+// PatchChunk is one `@@ ... @@` hunk, decomposed into typed operations
+// instead of a blob of `+`/`-`/` ` prefixed text.
+type PatchChunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []Line
+}
+
+// FilePatch describes everything the diff says about a single file: its
+// from/to identity (distinct when the file was renamed or copied), mode
+// changes, binary status, and its chunks.
+type FilePatch struct {
+	FromFile   string
+	ToFile     string
+	FromMode   string
+	ToMode     string
+	IsRename   bool
+	IsCopy     bool
+	IsNew      bool
+	IsDeleted  bool
+	IsBinary   bool
+	Similarity int
+	Chunks     []PatchChunk
+}
+
+// File returns the best single name to attribute this patch to: the new
+// path for renames/copies/modifications, the old path for deletions.
+func (fp FilePatch) File() string {
+	if fp.ToFile != "" && fp.ToFile != "/dev/null" {
+		return fp.ToFile
+	}
+	return fp.FromFile
+}
+
+// Patch is a fully parsed `git diff` (or `git diff --cached`) output.
+type Patch struct {
+	Files []FilePatch
+}
+
+// parsePatch parses unified diff text produced by `git diff` into a typed
+// Patch, handling rename/copy headers, mode changes, binary markers and
+// similarity index lines that the old regex-based parser silently dropped.
+func parsePatch(diffText string) *Patch {
+	patch := &Patch{}
+	lines := strings.Split(diffText, "\n")
+
+	var cur *FilePatch
+	var chunk *PatchChunk
+	oldLine, newLine := 0, 0
+
+	flushFile := func() {
+		if cur != nil {
+			if chunk != nil {
+				cur.Chunks = append(cur.Chunks, *chunk)
+				chunk = nil
+			}
+			patch.Files = append(patch.Files, *cur)
+			cur = nil
+		}
+	}
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushFile()
+			cur = &FilePatch{}
+			continue
+		case cur == nil:
+			continue
+		case strings.HasPrefix(line, "similarity index "):
+			pct := strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%")
+			if n, err := strconv.Atoi(pct); err == nil {
+				cur.Similarity = n
+			}
+			continue
+		case strings.HasPrefix(line, "rename from "):
+			cur.IsRename = true
+			cur.FromFile = strings.TrimPrefix(line, "rename from ")
+			continue
+		case strings.HasPrefix(line, "rename to "):
+			cur.IsRename = true
+			cur.ToFile = strings.TrimPrefix(line, "rename to ")
+			continue
+		case strings.HasPrefix(line, "copy from "):
+			cur.IsCopy = true
+			cur.FromFile = strings.TrimPrefix(line, "copy from ")
+			continue
+		case strings.HasPrefix(line, "copy to "):
+			cur.IsCopy = true
+			cur.ToFile = strings.TrimPrefix(line, "copy to ")
+			continue
+		case strings.HasPrefix(line, "new file mode "):
+			cur.IsNew = true
+			cur.ToMode = strings.TrimPrefix(line, "new file mode ")
+			continue
+		case strings.HasPrefix(line, "deleted file mode "):
+			cur.IsDeleted = true
+			cur.FromMode = strings.TrimPrefix(line, "deleted file mode ")
+			continue
+		case strings.HasPrefix(line, "old mode "):
+			cur.FromMode = strings.TrimPrefix(line, "old mode ")
+			continue
+		case strings.HasPrefix(line, "new mode "):
+			cur.ToMode = strings.TrimPrefix(line, "new mode ")
+			continue
+		case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"):
+			cur.IsBinary = true
+			continue
+		case strings.HasPrefix(line, "--- "):
+			f := strings.TrimPrefix(line, "--- ")
+			if f != "/dev/null" {
+				cur.FromFile = strings.TrimPrefix(strings.TrimPrefix(f, "a/"), "b/")
+			}
+			continue
+		case strings.HasPrefix(line, "+++ "):
+			f := strings.TrimPrefix(line, "+++ ")
+			if f != "/dev/null" {
+				cur.ToFile = strings.TrimPrefix(strings.TrimPrefix(f, "a/"), "b/")
+			}
+			continue
+		case strings.HasPrefix(line, "@@"):
+			if chunk != nil {
+				cur.Chunks = append(cur.Chunks, *chunk)
+			}
+			oldStart, oldLines, newStart, newLines := parseHunkHeader(line)
+			chunk = &PatchChunk{OldStart: oldStart, OldLines: oldLines, NewStart: newStart, NewLines: newLines}
+			oldLine, newLine = oldStart, newStart
+			continue
+		case chunk != nil && strings.HasPrefix(line, "+"):
+			chunk.Lines = append(chunk.Lines, Line{Op: Add, Text: strings.TrimPrefix(line, "+")})
+			newLine++
+			continue
+		case chunk != nil && strings.HasPrefix(line, "-"):
+			chunk.Lines = append(chunk.Lines, Line{Op: Delete, Text: strings.TrimPrefix(line, "-")})
+			oldLine++
+			continue
+		case chunk != nil && strings.HasPrefix(line, " "):
+			chunk.Lines = append(chunk.Lines, Line{Op: Equal, Text: strings.TrimPrefix(line, " ")})
+			oldLine++
+			newLine++
+			continue
+		case strings.HasPrefix(line, "\\ No newline at end of file"):
+			continue
+		}
+	}
+	flushFile()
+
+	return patch
+}
+
+// parseHunkHeader parses a `@@ -a,b +c,d @@` line into its four integers,
+// defaulting the line-count to 1 when git omits it (a single-line hunk).
+func parseHunkHeader(line string) (oldStart, oldLines, newStart, newLines int) {
+	oldLines, newLines = 1, 1
+	body := strings.TrimPrefix(line, "@@ ")
+	if idx := strings.Index(body, " @@"); idx >= 0 {
+		body = body[:idx]
+	}
+	parts := strings.Fields(body)
+	for _, p := range parts {
+		switch {
+		case strings.HasPrefix(p, "-"):
+			oldStart, oldLines = parseRange(p[1:])
+		case strings.HasPrefix(p, "+"):
+			newStart, newLines = parseRange(p[1:])
+		}
+	}
+	return
+}
+
+func parseRange(s string) (start, count int) {
+	count = 1
+	parts := strings.SplitN(s, ",", 2)
+	start, _ = strconv.Atoi(parts[0])
+	if len(parts) == 2 {
+		count, _ = strconv.Atoi(parts[1])
+	}
+	return
+}
+
+// BuildPartialPatch renders a standalone unified diff for file containing
+// only keep -- a subset of the hunks AnalyzeDiff found for it -- so
+// MultiCommitManager.stageFilePartial can feed it to `git apply --cached`
+// and commit just those hunks while leaving the rest of the file staged
+// for a later commit.
+//
+// applied lists hunks of the same file an earlier commit in the current
+// multi-commit run already applied; keep's hunks carry OldStart/OldLines
+// from the original diff (parsed once, against the pre-image HEAD had
+// before any of this run's commits), so they're re-addressed here
+// against however much applied has already shifted the file in HEAD.
+// remaining is how many of the file's hunks are left for a later commit
+// after this one -- 0 marks this as the commit that finally removes a
+// deleted file or completes a brand new one.
+func BuildPartialPatch(file string, keep, applied []Hunk, remaining int) string {
+	if len(keep) == 0 {
+		return ""
+	}
+
+	isNew := keep[0].IsNewFile && len(applied) == 0
+	isDeleted := keep[0].IsDeletedFile && remaining == 0
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", file, file)
+
+	switch {
+	case isNew:
+		mode := keep[0].Mode
+		if mode == "" {
+			mode = "100644"
+		}
+		fmt.Fprintf(&b, "new file mode %s\n", mode)
+		fmt.Fprintf(&b, "--- /dev/null\n+++ b/%s\n", file)
+	case isDeleted:
+		mode := keep[0].Mode
+		if mode == "" {
+			mode = "100644"
+		}
+		fmt.Fprintf(&b, "deleted file mode %s\n", mode)
+		fmt.Fprintf(&b, "--- a/%s\n+++ /dev/null\n", file)
+	default:
+		fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", file, file)
+	}
+
+	// appliedDeltaBefore sums the net line delta of every already-applied
+	// hunk positioned before oldStart, shifting a kept hunk's pre-image
+	// coordinates from the original diff's numbering to HEAD's current
+	// one. This orders by OldStart, which is unique per hunk for any real
+	// modify/delete diff; it degenerates to 0 for a brand new file, whose
+	// hunks all carry "-0,0" -- harmless in practice since `git diff`
+	// never splits a new file's content into more than one hunk.
+	appliedDeltaBefore := func(oldStart int) int {
+		delta := 0
+		for _, h := range applied {
+			if h.OldStart < oldStart {
+				delta += (h.EndLine - h.StartLine) - h.OldLines
+			}
+		}
+		return delta
+	}
+
+	within := 0
+	for _, h := range keep {
+		oldStart := h.OldStart + appliedDeltaBefore(h.OldStart)
+		newLines := h.EndLine - h.StartLine
+
+		// newStart tracks oldStart plus whatever this patch's own earlier
+		// kept hunks (within) have already shifted the file by, with the
+		// same +1/-1 correction `git diff` itself applies around a
+		// zero-length range: a pure insertion's new range starts one past
+		// its (zero-length) old anchor, and a pure deletion's new range
+		// -- itself zero-length -- starts one before it.
+		newStart := oldStart + within
+		switch {
+		case h.OldLines == 0:
+			newStart++
+		case newLines == 0:
+			newStart--
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, h.OldLines, newStart, newLines)
+		content := h.Content
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		b.WriteString(content)
+
+		within += newLines - h.OldLines
+	}
+
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package splitter
+
+import (
+	"strings"
+	"testing"
+)
+
+// realDiffHunks parses a real unified diff and returns the Hunks for the
+// first file it touches, the same path ProcessStagedChanges takes.
+func realDiffHunks(t *testing.T, diff string) []Hunk {
+	t.Helper()
+	patch := parsePatch(diff)
+	if len(patch.Files) != 1 {
+		t.Fatalf("expected exactly one file in diff, got %d", len(patch.Files))
+	}
+	s := &Splitter{}
+	return s.hunksForFilePatch(patch.Files[0])
+}
+
+func TestBuildPartialPatchModifySplitAcrossCommits(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -2,0 +3,2 @@
++line3a
++line3b
+@@ -10,0 +13,1 @@
++line13
+`
+	hunks := realDiffHunks(t, diff)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(hunks))
+	}
+
+	// Commit 1 keeps only the first hunk; the second is deferred.
+	first := BuildPartialPatch("a.go", hunks[:1], nil, 1)
+	if !strings.Contains(first, "@@ -2,0 +3,2 @@\n") {
+		t.Errorf("first patch has wrong hunk header:\n%s", first)
+	}
+	reparsed := parsePatch(first)
+	if len(reparsed.Files) != 1 || len(reparsed.Files[0].Chunks) != 1 {
+		t.Fatalf("first patch didn't round-trip through parsePatch:\n%s", first)
+	}
+
+	// Commit 2 keeps the second hunk, now that the first has already
+	// landed in HEAD -- its old-side coordinates must shift by the first
+	// hunk's net +2 lines.
+	second := BuildPartialPatch("a.go", hunks[1:], hunks[:1], 0)
+	if !strings.Contains(second, "@@ -12,0 +13,1 @@\n") {
+		t.Errorf("second patch has wrong hunk header (expected old-start shifted by +2):\n%s", second)
+	}
+}
+
+func TestBuildPartialPatchNewFileFirstCommitOnly(t *testing.T) {
+	diff := `diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..1111111
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package new
++
+@@ -0,0 +5,1 @@
++func F() {}
+`
+	hunks := realDiffHunks(t, diff)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(hunks))
+	}
+
+	first := BuildPartialPatch("new.go", hunks[:1], nil, 1)
+	if !strings.Contains(first, "new file mode") || !strings.Contains(first, "--- /dev/null") {
+		t.Errorf("first commit of a new file must carry a new file mode header:\n%s", first)
+	}
+
+	second := BuildPartialPatch("new.go", hunks[1:], hunks[:1], 0)
+	if strings.Contains(second, "new file mode") || strings.Contains(second, "/dev/null") {
+		t.Errorf("second commit of an already-created file must not repeat the new file header:\n%s", second)
+	}
+}
+
+func TestBuildPartialPatchDeletedFileLastCommitOnly(t *testing.T) {
+	diff := `diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 7777777..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1,1 +0,0 @@
+-package gone
+@@ -3,1 +0,0 @@
+-func F() {}
+`
+	hunks := realDiffHunks(t, diff)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(hunks))
+	}
+
+	first := BuildPartialPatch("gone.go", hunks[:1], nil, 1)
+	if strings.Contains(first, "deleted file mode") || strings.Contains(first, "/dev/null") {
+		t.Errorf("a commit that doesn't remove the last of a file's content must not mark it deleted:\n%s", first)
+	}
+
+	last := BuildPartialPatch("gone.go", hunks[1:], hunks[:1], 0)
+	if !strings.Contains(last, "deleted file mode") || !strings.Contains(last, "+++ /dev/null") {
+		t.Errorf("the commit removing the file's last hunk must carry the deleted file header:\n%s", last)
+	}
+}
@@ -1,486 +1,772 @@
-package splitter
-
-import (
-	"fmt"
-	"path/filepath"
-	"regexp"
-	"strings"
-
-	"github.com/Harri200191/gitmind/internal/config"
-)
-
-// Change represents a logical change in the codebase
-type Change struct {
-	Files     []string               `json:"files"`
-	Functions []string               `json:"functions"`
-	Hunks     []Hunk                 `json:"hunks"`
-	Message   string                 `json:"message"`
-	Metadata  map[string]interface{} `json:"metadata"`
-}
-
-// Hunk represents a diff hunk
-type Hunk struct {
-	File      string `json:"file"`
-	StartLine int    `json:"start_line"`
-	EndLine   int    `json:"end_line"`
-	Content   string `json:"content"`
-	Type      string `json:"type"` // "add", "remove", "modify"
-}
-
-// Cluster represents a group of related changes
-type Cluster struct {
-	Changes     []Change `json:"changes"`
-	Score       float64  `json:"score"`
-	Description string   `json:"description"`
-}
-
-// Splitter handles multi-commit splitting logic
-type Splitter struct {
-	config config.Config
-}
-
-// New creates a new splitter instance
-func New(cfg config.Config) *Splitter {
-	return &Splitter{config: cfg}
-}
-
-// AnalyzeDiff parses a git diff and extracts semantic information
-func (s *Splitter) AnalyzeDiff(diff string) ([]Change, error) {
-	var changes []Change
-
-	// Parse the diff into hunks
-	hunks := s.parseDiffHunks(diff)
-
-	// Group hunks by files
-	fileGroups := s.groupHunksByFile(hunks)
-
-	// Analyze each file group for semantic changes
-	for file, fileHunks := range fileGroups {
-		change, err := s.analyzeFileChanges(file, fileHunks)
-		if err != nil {
-			// If analysis fails, treat as a simple file change
-			change = Change{
-				Files: []string{file},
-				Hunks: fileHunks,
-				Metadata: map[string]interface{}{
-					"analysis_failed": true,
-				},
-			}
-		}
-		changes = append(changes, change)
-	}
-
-	return changes, nil
-}
-
-// ClusterChanges groups related changes into logical commits
-func (s *Splitter) ClusterChanges(changes []Change) ([]Cluster, error) {
-	if !s.config.MultiCommit.Enabled || len(changes) <= 1 {
-		return []Cluster{{Changes: changes, Score: 1.0}}, nil
-	}
-
-	// Calculate similarity matrix
-	similarities := s.calculateSimilarities(changes)
-
-	// Perform clustering based on similarity scores
-	clusters := s.performClustering(changes, similarities)
-
-	// Limit number of clusters
-	if len(clusters) > s.config.MultiCommit.MaxClusters {
-		clusters = s.mergeClusters(clusters, s.config.MultiCommit.MaxClusters)
-	}
-
-	return clusters, nil
-}
-
-// parseDiffHunks extracts individual hunks from a git diff
-func (s *Splitter) parseDiffHunks(diff string) []Hunk {
-	var hunks []Hunk
-	lines := strings.Split(diff, "\n")
-
-	var currentFile string
-	var currentHunk *Hunk
-
-	for _, line := range lines {
-		// File header
-		if strings.HasPrefix(line, "+++ b/") {
-			currentFile = strings.TrimPrefix(line, "+++ b/")
-			continue
-		}
-
-		// Hunk header
-		if strings.HasPrefix(line, "@@") {
-			if currentHunk != nil {
-				hunks = append(hunks, *currentHunk)
-			}
-
-			// Parse hunk location
-			re := regexp.MustCompile(`@@ -(\d+),?\d* \+(\d+),?\d* @@`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) >= 3 {
-				currentHunk = &Hunk{
-					File:      currentFile,
-					StartLine: parseInt(matches[2]),
-					Content:   "",
-				}
-			}
-			continue
-		}
-
-		// Content lines
-		if currentHunk != nil && (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, " ")) {
-			currentHunk.Content += line + "\n"
-
-			// Determine hunk type
-			if strings.HasPrefix(line, "+") {
-				if currentHunk.Type == "" {
-					currentHunk.Type = "add"
-				} else if currentHunk.Type == "remove" {
-					currentHunk.Type = "modify"
-				}
-			} else if strings.HasPrefix(line, "-") {
-				if currentHunk.Type == "" {
-					currentHunk.Type = "remove"
-				} else if currentHunk.Type == "add" {
-					currentHunk.Type = "modify"
-				}
-			}
-		}
-	}
-
-	// Don't forget the last hunk
-	if currentHunk != nil {
-		hunks = append(hunks, *currentHunk)
-	}
-
-	return hunks
-}
-
-// groupHunksByFile groups hunks by their file path
-func (s *Splitter) groupHunksByFile(hunks []Hunk) map[string][]Hunk {
-	groups := make(map[string][]Hunk)
-	for _, hunk := range hunks {
-		groups[hunk.File] = append(groups[hunk.File], hunk)
-	}
-	return groups
-}
-
-// analyzeFileChanges performs AST analysis for Go files
-func (s *Splitter) analyzeFileChanges(file string, hunks []Hunk) (Change, error) {
-	change := Change{
-		Files:    []string{file},
-		Hunks:    hunks,
-		Metadata: make(map[string]interface{}),
-	}
-
-	// Only analyze Go files for now
-	if !strings.HasSuffix(file, ".go") {
-		return change, nil
-	}
-
-	// Try to parse the file and extract function information
-	functions, err := s.extractFunctionsFromHunks(file, hunks)
-	if err != nil {
-		return change, err
-	}
-
-	change.Functions = functions
-	change.Metadata["language"] = "go"
-
-	return change, nil
-}
-
-// extractFunctionsFromHunks analyzes hunks to identify modified functions
-func (s *Splitter) extractFunctionsFromHunks(file string, hunks []Hunk) ([]string, error) {
-	var functions []string
-
-	for _, hunk := range hunks {
-		// Simple heuristic: look for function signatures in the diff
-		lines := strings.Split(hunk.Content, "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
-				content := strings.TrimPrefix(strings.TrimPrefix(line, "+"), "-")
-				content = strings.TrimSpace(content)
-
-				// Look for function declarations
-				if matched, _ := regexp.MatchString(`^func\s+(\w+)`, content); matched {
-					re := regexp.MustCompile(`^func\s+(\w+)`)
-					matches := re.FindStringSubmatch(content)
-					if len(matches) > 1 {
-						functions = append(functions, matches[1])
-					}
-				}
-			}
-		}
-	}
-
-	return unique(functions), nil
-}
-
-// calculateSimilarities computes semantic similarity between changes
-func (s *Splitter) calculateSimilarities(changes []Change) [][]float64 {
-	n := len(changes)
-	similarities := make([][]float64, n)
-	for i := range similarities {
-		similarities[i] = make([]float64, n)
-	}
-
-	for i := 0; i < n; i++ {
-		for j := i; j < n; j++ {
-			if i == j {
-				similarities[i][j] = 1.0
-			} else {
-				score := s.calculateSimilarity(changes[i], changes[j])
-				similarities[i][j] = score
-				similarities[j][i] = score
-			}
-		}
-	}
-
-	return similarities
-}
-
-// calculateSimilarity computes similarity score between two changes
-func (s *Splitter) calculateSimilarity(a, b Change) float64 {
-	var score float64
-
-	// File path similarity
-	fileScore := s.calculateFilePathSimilarity(a.Files, b.Files)
-	score += fileScore * 0.3
-
-	// Function similarity
-	funcScore := s.calculateFunctionSimilarity(a.Functions, b.Functions)
-	score += funcScore * 0.4
-
-	// Content similarity (basic keyword matching)
-	contentScore := s.calculateContentSimilarity(a.Hunks, b.Hunks)
-	score += contentScore * 0.3
-
-	return score
-}
-
-// performClustering groups changes based on similarity scores
-func (s *Splitter) performClustering(changes []Change, similarities [][]float64) []Cluster {
-	n := len(changes)
-	clusters := make([]Cluster, 0)
-	used := make([]bool, n)
-
-	threshold := s.config.MultiCommit.SimilarityThreshold
-
-	for i := 0; i < n; i++ {
-		if used[i] {
-			continue
-		}
-
-		cluster := Cluster{
-			Changes: []Change{changes[i]},
-			Score:   1.0,
-		}
-		used[i] = true
-
-		// Find similar changes to group together
-		for j := i + 1; j < n; j++ {
-			if !used[j] && similarities[i][j] >= threshold {
-				cluster.Changes = append(cluster.Changes, changes[j])
-				cluster.Score = (cluster.Score + similarities[i][j]) / 2
-				used[j] = true
-			}
-		}
-
-		cluster.Description = s.generateClusterDescription(cluster)
-		clusters = append(clusters, cluster)
-	}
-
-	return clusters
-}
-
-// Helper functions
-func parseInt(s string) int {
-	// Simple integer parsing, ignoring errors for brevity
-	var result int
-	fmt.Sscanf(s, "%d", &result)
-	return result
-} 
-
-func (s *Splitter) calculateFilePathSimilarity(files1, files2 []string) float64 {
-	if len(files1) == 0 || len(files2) == 0 {
-		return 0.0
-	}
-
-	// Check for overlapping files or similar paths
-	for _, f1 := range files1 {
-		for _, f2 := range files2 {
-			if f1 == f2 {
-				return 1.0
-			}
-			// Check if files are in the same directory
-			if filepath.Dir(f1) == filepath.Dir(f2) {
-				return 0.7
-			}
-			// Check if files have similar names
-			if strings.Contains(f1, strings.TrimSuffix(filepath.Base(f2), filepath.Ext(f2))) ||
-				strings.Contains(f2, strings.TrimSuffix(filepath.Base(f1), filepath.Ext(f1))) {
-				return 0.5
-			}
-		}
-	}
-
-	return 0.0
-}
-
-func (s *Splitter) calculateFunctionSimilarity(funcs1, funcs2 []string) float64 {
-	if len(funcs1) == 0 || len(funcs2) == 0 {
-		return 0.0
-	}
-
-	common := 0
-	for _, f1 := range funcs1 {
-		for _, f2 := range funcs2 {
-			if f1 == f2 {
-				common++
-				break
-			}
-		}
-	}
-
-	return float64(common) / float64(max(len(funcs1), len(funcs2)))
-}
-
-func (s *Splitter) calculateContentSimilarity(hunks1, hunks2 []Hunk) float64 {
-	// Simple keyword-based similarity
-	keywords1 := s.extractKeywords(hunks1)
-	keywords2 := s.extractKeywords(hunks2)
-
-	if len(keywords1) == 0 || len(keywords2) == 0 {
-		return 0.0
-	}
-
-	common := 0
-	for kw1 := range keywords1 {
-		if keywords2[kw1] {
-			common++
-		}
-	}
-
-	return float64(common) / float64(max(len(keywords1), len(keywords2)))
-}
-
-func (s *Splitter) extractKeywords(hunks []Hunk) map[string]bool {
-	keywords := make(map[string]bool)
-
-	for _, hunk := range hunks {
-		lines := strings.Split(hunk.Content, "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
-				content := strings.TrimPrefix(strings.TrimPrefix(line, "+"), "-")
-				words := strings.Fields(content)
-				for _, word := range words {
-					// Simple keyword extraction
-					word = strings.Trim(word, "(){}[].,;:")
-					if len(word) > 3 && !isCommonWord(word) {
-						keywords[strings.ToLower(word)] = true
-					}
-				}
-			}
-		}
-	}
-
-	return keywords
-}
-
-func (s *Splitter) mergeClusters(clusters []Cluster, maxClusters int) []Cluster {
-	if len(clusters) <= maxClusters {
-		return clusters
-	}
-
-	// Simple strategy: merge smallest clusters first
-	for len(clusters) > maxClusters {
-		// Find two smallest clusters
-		minIdx1, minIdx2 := 0, 1
-		minSize := len(clusters[0].Changes) + len(clusters[1].Changes)
-
-		for i := 0; i < len(clusters); i++ {
-			for j := i + 1; j < len(clusters); j++ {
-				size := len(clusters[i].Changes) + len(clusters[j].Changes)
-				if size < minSize {
-					minIdx1, minIdx2 = i, j
-					minSize = size
-				}
-			}
-		}
-
-		// Merge clusters
-		merged := Cluster{
-			Changes: append(clusters[minIdx1].Changes, clusters[minIdx2].Changes...),
-			Score:   (clusters[minIdx1].Score + clusters[minIdx2].Score) / 2,
-		}
-		merged.Description = s.generateClusterDescription(merged)
-
-		// Remove old clusters and add merged one
-		newClusters := make([]Cluster, 0, len(clusters)-1)
-		for i, cluster := range clusters {
-			if i != minIdx1 && i != minIdx2 {
-				newClusters = append(newClusters, cluster)
-			}
-		}
-		newClusters = append(newClusters, merged)
-		clusters = newClusters
-	}
-
-	return clusters
-}
-
-func (s *Splitter) generateClusterDescription(cluster Cluster) string {
-	if len(cluster.Changes) == 1 {
-		change := cluster.Changes[0]
-		if len(change.Functions) > 0 {
-			return fmt.Sprintf("Modify %s", strings.Join(change.Functions, ", "))
-		}
-		return fmt.Sprintf("Update %s", strings.Join(change.Files, ", "))
-	}
-
-	// Multiple changes
-	allFiles := make(map[string]bool)
-	allFunctions := make(map[string]bool)
-
-	for _, change := range cluster.Changes {
-		for _, file := range change.Files {
-			allFiles[file] = true
-		}
-		for _, fn := range change.Functions {
-			allFunctions[fn] = true
-		}
-	}
-
-	if len(allFunctions) > 0 {
-		functions := make([]string, 0, len(allFunctions))
-		for fn := range allFunctions {
-			functions = append(functions, fn)
-		}
-		return fmt.Sprintf("Update %s functions", strings.Join(functions, ", "))
-	}
-
-	return fmt.Sprintf("Update %d files", len(allFiles))
-}
-
-// Helper functions
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func isCommonWord(word string) bool {
-	commonWords := map[string]bool{
-		"the": true, "and": true, "for": true, "are": true, "but": true,
-		"not": true, "you": true, "all": true, "can": true, "had": true,
-		"her": true, "was": true, "one": true, "our": true, "out": true,
-		"day": true, "get": true, "has": true, "him": true, "his": true,
-		"how": true, "its": true, "new": true, "now": true, "old": true,
-		"see": true, "two": true, "who": true, "boy": true, "did": true,
-		"may": true, "put": true, "say": true, "she": true, "too": true,
-		"use": true, "var": true, "nil": true, "err": true, "int": true,
-	}
-	return commonWords[strings.ToLower(word)]
-}
+package splitter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/analyzer"
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// goAnalyzer is the LanguageAnalyzer used for .go files; a package var so
+// it can be swapped for a fake in callers that don't want to touch the
+// working tree.
+var goAnalyzer analyzer.LanguageAnalyzer = analyzer.GoAnalyzer{}
+
+// Change represents a logical change in the codebase
+type Change struct {
+	Files []string `json:"files"`
+	// Functions holds a display-friendly name for every symbol touched
+	// (Symbol.String(), e.g. "(*Splitter).parseDiffHunks"), kept populated
+	// even when Symbols itself is empty (non-Go files, or files that only
+	// matched the regex fallback) so existing consumers don't need to
+	// branch on which path produced it.
+	Functions []string `json:"functions"`
+	// Symbols holds the AST-derived functions/methods/types/const-var
+	// groups a hunk actually touches, populated for Go files that parsed
+	// successfully. Unparseable or non-Go files leave this nil and rely on
+	// the Functions fallback instead.
+	Symbols []analyzer.Symbol `json:"symbols,omitempty"`
+	Hunks   []Hunk            `json:"hunks"`
+	Message string            `json:"message"`
+	// Kind classifies the change so the clusterer can weight it
+	// differently: "modify" (default), "rename", "copy", "add", "delete",
+	// "binary", "generated", or "vendored".
+	Kind string `json:"kind"`
+	// Attributes holds the resolved .gitattributes entries for this
+	// change's file (e.g. "linguist-generated": "true"), populated when
+	// MultiCommit.RespectGitAttributes is enabled.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Language is a human-readable hint ("Go", "TypeScript") derived from
+	// a diff=<driver> attribute or the file extension, fed to the LLM
+	// prompt for context.
+	Language string                 `json:"language,omitempty"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// Hunk represents a diff hunk. It is derived from a parsed FilePatch, so
+// renames/copies and binary files carry their from/to identity instead of
+// being inferred from `+++`/`---` headers alone.
+type Hunk struct {
+	File      string `json:"file"`
+	FromFile  string `json:"from_file,omitempty"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Content   string `json:"content"`
+	Type      string `json:"type"` // "add", "remove", "modify"
+	IsBinary  bool   `json:"is_binary,omitempty"`
+
+	// OldStart/OldLines are this hunk's pre-image coordinates, carried
+	// alongside StartLine/EndLine (the post-image) so BuildPartialPatch
+	// can re-synthesize a standalone, correctly-addressed hunk for
+	// MultiCommitManager.stageFilePartial instead of only the new-side
+	// range toAnalyzerHunks and the content scanners below need.
+	OldStart int `json:"old_start,omitempty"`
+	OldLines int `json:"old_lines,omitempty"`
+
+	// IsNewFile/IsDeletedFile/Mode mirror the owning FilePatch's
+	// IsNew/IsDeleted/ToMode|FromMode, so a partial patch can carry the
+	// right "new file mode"/"deleted file mode" header without needing
+	// the FilePatch itself around.
+	IsNewFile     bool   `json:"is_new_file,omitempty"`
+	IsDeletedFile bool   `json:"is_deleted_file,omitempty"`
+	Mode          string `json:"mode,omitempty"`
+}
+
+// Cluster represents a group of related changes
+type Cluster struct {
+	Changes     []Change `json:"changes"`
+	Score       float64  `json:"score"`
+	Description string   `json:"description"`
+}
+
+// Splitter handles multi-commit splitting logic
+type Splitter struct {
+	config config.Config
+}
+
+// New creates a new splitter instance
+func New(cfg config.Config) *Splitter {
+	return &Splitter{config: cfg}
+}
+
+// AnalyzeDiff parses a git diff and extracts semantic information
+func (s *Splitter) AnalyzeDiff(diff string) ([]Change, error) {
+	var changes []Change
+
+	patch := parsePatch(diff)
+
+	var attrPatterns []attrPattern
+	if s.config.MultiCommit.RespectGitAttributes {
+		attrPatterns = loadGitAttributes()
+	}
+
+	for _, fp := range patch.Files {
+		file := fp.File()
+		fileHunks := s.hunksForFilePatch(fp)
+
+		change, err := s.analyzeFileChanges(file, fileHunks)
+		if err != nil {
+			// If analysis fails, treat as a simple file change
+			change = Change{
+				Files: []string{file},
+				Hunks: fileHunks,
+				Metadata: map[string]interface{}{
+					"analysis_failed": true,
+				},
+			}
+		}
+		change.Kind = classifyChangeKind(fp)
+		if fp.IsRename && fp.FromFile != "" && fp.FromFile != fp.ToFile {
+			change.Metadata["renamed_from"] = fp.FromFile
+		}
+
+		if s.config.MultiCommit.UseBlame {
+			if attributions := blameAttributionForFilePatch(fp); len(attributions) > 0 {
+				change.Metadata["blame"] = attributions
+			}
+		}
+
+		if s.config.MultiCommit.RespectGitAttributes {
+			attrs := matchGitAttributes(attrPatterns, file)
+			change.Attributes = attrs
+			change.Language = languageFromAttributes(attrs, file)
+			if attrs["linguist-generated"] == "true" {
+				change.Kind = "generated"
+			} else if attrs["linguist-vendored"] == "true" {
+				change.Kind = "vendored"
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// hunksForFilePatch converts a single FilePatch's chunks into the legacy
+// Hunk shape the rest of the package (and the LLM prompt builder) expects.
+func (s *Splitter) hunksForFilePatch(fp FilePatch) []Hunk {
+	var hunks []Hunk
+	if fp.IsBinary {
+		return []Hunk{{File: fp.File(), FromFile: fp.FromFile, Type: "binary", IsBinary: true}}
+	}
+
+	for _, chunk := range fp.Chunks {
+		hunk := Hunk{
+			File:          fp.File(),
+			FromFile:      fp.FromFile,
+			StartLine:     chunk.NewStart,
+			EndLine:       chunk.NewStart + chunk.NewLines,
+			OldStart:      chunk.OldStart,
+			OldLines:      chunk.OldLines,
+			IsNewFile:     fp.IsNew,
+			IsDeletedFile: fp.IsDeleted,
+		}
+		if fp.IsNew {
+			hunk.Mode = fp.ToMode
+		} else if fp.IsDeleted {
+			hunk.Mode = fp.FromMode
+		}
+
+		var content strings.Builder
+		for _, l := range chunk.Lines {
+			switch l.Op {
+			case Add:
+				content.WriteString("+" + l.Text + "\n")
+				if hunk.Type == "" {
+					hunk.Type = "add"
+				} else if hunk.Type == "remove" {
+					hunk.Type = "modify"
+				}
+			case Delete:
+				content.WriteString("-" + l.Text + "\n")
+				if hunk.Type == "" {
+					hunk.Type = "remove"
+				} else if hunk.Type == "add" {
+					hunk.Type = "modify"
+				}
+			default:
+				content.WriteString(" " + l.Text + "\n")
+			}
+		}
+		hunk.Content = content.String()
+		hunks = append(hunks, hunk)
+	}
+
+	return hunks
+}
+
+// ClusterChanges groups related changes into logical commits
+func (s *Splitter) ClusterChanges(changes []Change) ([]Cluster, error) {
+	if !s.config.MultiCommit.Enabled || len(changes) <= 1 {
+		return []Cluster{{Changes: changes, Score: 1.0}}, nil
+	}
+
+	// Calculate similarity matrix
+	similarities := s.calculateSimilarities(changes)
+
+	// Perform clustering based on similarity scores
+	clusters := s.performClustering(changes, similarities)
+
+	// Limit number of clusters
+	if len(clusters) > s.config.MultiCommit.MaxClusters {
+		clusters = s.mergeClusters(clusters, s.config.MultiCommit.MaxClusters)
+	}
+
+	return clusters, nil
+}
+
+// parseDiffHunks extracts individual hunks from an entire git diff by
+// parsing it into typed FilePatches first, so renames, binary files and
+// mode changes survive instead of being dropped by prefix-matching on
+// `+++`/`@@` lines.
+func (s *Splitter) parseDiffHunks(diff string) []Hunk {
+	patch := parsePatch(diff)
+
+	var hunks []Hunk
+	for _, fp := range patch.Files {
+		hunks = append(hunks, s.hunksForFilePatch(fp)...)
+	}
+	return hunks
+}
+
+// classifyChangeKind maps a FilePatch onto the coarse Change.Kind used by
+// the clusterer to avoid lumping renames/binaries in with logic changes.
+func classifyChangeKind(fp FilePatch) string {
+	switch {
+	case fp.IsBinary:
+		return "binary"
+	case fp.IsRename:
+		return "rename"
+	case fp.IsCopy:
+		return "copy"
+	case fp.IsNew:
+		return "add"
+	case fp.IsDeleted:
+		return "delete"
+	default:
+		return "modify"
+	}
+}
+
+// analyzeFileChanges performs AST analysis for Go files
+func (s *Splitter) analyzeFileChanges(file string, hunks []Hunk) (Change, error) {
+	change := Change{
+		Files:    []string{file},
+		Hunks:    hunks,
+		Metadata: make(map[string]interface{}),
+	}
+
+	// Only analyze Go files for now
+	if !strings.HasSuffix(file, ".go") {
+		return change, nil
+	}
+
+	symbols, err := goAnalyzer.Symbols(file, toAnalyzerHunks(hunks))
+	if err != nil {
+		// Unparseable post-image (syntax error mid-edit, deleted file,
+		// generated file the parser chokes on): fall back to the regex
+		// heuristic rather than failing the whole change.
+		functions, ferr := s.extractFunctionsFromHunks(file, hunks)
+		if ferr != nil {
+			return change, ferr
+		}
+		change.Functions = functions
+		change.Metadata["language"] = "go"
+		return change, nil
+	}
+
+	change.Symbols = symbols
+	change.Functions = symbolNames(symbols)
+	change.Metadata["language"] = "go"
+
+	return change, nil
+}
+
+// toAnalyzerHunks narrows splitter's Hunk down to the line range
+// analyzer.Hunk needs, keeping the analyzer package free of a dependency
+// on splitter.
+func toAnalyzerHunks(hunks []Hunk) []analyzer.Hunk {
+	out := make([]analyzer.Hunk, len(hunks))
+	for i, h := range hunks {
+		out[i] = analyzer.Hunk{StartLine: h.StartLine, EndLine: h.EndLine}
+	}
+	return out
+}
+
+func symbolNames(symbols []analyzer.Symbol) []string {
+	names := make([]string, len(symbols))
+	for i, sym := range symbols {
+		names[i] = sym.String()
+	}
+	return names
+}
+
+// extractFunctionsFromHunks is the regex-based fallback used when
+// goAnalyzer can't parse file (non-Go files, or Go files with a syntax
+// error in the working tree). It only recognizes plain `func Name(...)`
+// declarations, missing methods, generics, and type/const/var groups -
+// callers should prefer analyzeFileChanges's AST path.
+func (s *Splitter) extractFunctionsFromHunks(file string, hunks []Hunk) ([]string, error) {
+	var functions []string
+
+	for _, hunk := range hunks {
+		// Simple heuristic: look for function signatures in the diff
+		lines := strings.Split(hunk.Content, "\n")
+		for _, line := range lines {
+			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+				content := strings.TrimPrefix(strings.TrimPrefix(line, "+"), "-")
+				content = strings.TrimSpace(content)
+
+				// Look for function declarations
+				if matched, _ := regexp.MatchString(`^func\s+(\w+)`, content); matched {
+					re := regexp.MustCompile(`^func\s+(\w+)`)
+					matches := re.FindStringSubmatch(content)
+					if len(matches) > 1 {
+						functions = append(functions, matches[1])
+					}
+				}
+			}
+		}
+	}
+
+	return unique(functions), nil
+}
+
+// calculateSimilarities computes semantic similarity between changes
+func (s *Splitter) calculateSimilarities(changes []Change) [][]float64 {
+	n := len(changes)
+	similarities := make([][]float64, n)
+	for i := range similarities {
+		similarities[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			if i == j {
+				similarities[i][j] = 1.0
+			} else {
+				score := s.calculateSimilarity(changes[i], changes[j])
+				similarities[i][j] = score
+				similarities[j][i] = score
+			}
+		}
+	}
+
+	return similarities
+}
+
+// calculateSimilarity computes similarity score between two changes
+func (s *Splitter) calculateSimilarity(a, b Change) float64 {
+	var score float64
+
+	// Renames and binary files are structural, not logical: don't let a
+	// rename cluster with unrelated logic changes just because their
+	// directories happen to match.
+	if (a.Kind == "rename" || a.Kind == "binary") != (b.Kind == "rename" || b.Kind == "binary") {
+		return 0.0
+	}
+
+	// Generated/vendored files should stay in their own cluster rather
+	// than mixing with hand-written code, weighted by how strongly the
+	// configured attribute should pull them apart.
+	if isolated, weight := s.attributeIsolation(a, b); isolated {
+		return 1.0 - weight
+	}
+
+	// File path similarity
+	fileScore := s.calculateFilePathSimilarity(a.Files, b.Files)
+	score += fileScore * 0.3
+
+	// Function similarity
+	funcScore := s.calculateFunctionSimilarity(a, b)
+	score += funcScore * 0.4
+
+	// Content similarity (basic keyword matching)
+	contentScore := s.calculateContentSimilarity(a.Hunks, b.Hunks)
+	score += contentScore * 0.3
+
+	return score
+}
+
+// attributeIsolation reports whether a and b should be kept apart because
+// exactly one of them is a generated/vendored file, and by how much
+// (scaled by the configured per-attribute weight, 0..1).
+func (s *Splitter) attributeIsolation(a, b Change) (bool, float64) {
+	aTagged := a.Kind == "generated" || a.Kind == "vendored"
+	bTagged := b.Kind == "generated" || b.Kind == "vendored"
+	if aTagged == bTagged && a.Kind == b.Kind {
+		return false, 0
+	}
+	if !aTagged && !bTagged {
+		return false, 0
+	}
+
+	kind := a.Kind
+	if !aTagged {
+		kind = b.Kind
+	}
+
+	weight := 1.0
+	if w, ok := s.config.MultiCommit.AttributeWeights[attributeKeyForKind(kind)]; ok {
+		weight = w
+	}
+	return true, weight
+}
+
+func attributeKeyForKind(kind string) string {
+	if kind == "vendored" {
+		return "linguist-vendored"
+	}
+	return "linguist-generated"
+}
+
+// performClustering groups changes based on similarity scores
+func (s *Splitter) performClustering(changes []Change, similarities [][]float64) []Cluster {
+	n := len(changes)
+	clusters := make([]Cluster, 0)
+	used := make([]bool, n)
+
+	threshold := s.config.MultiCommit.SimilarityThreshold
+
+	for i := 0; i < n; i++ {
+		if used[i] {
+			continue
+		}
+
+		cluster := Cluster{
+			Changes: []Change{changes[i]},
+			Score:   1.0,
+		}
+		used[i] = true
+
+		// Find similar changes to group together
+		for j := i + 1; j < n; j++ {
+			if !used[j] && similarities[i][j] >= threshold {
+				cluster.Changes = append(cluster.Changes, changes[j])
+				cluster.Score = (cluster.Score + similarities[i][j]) / 2
+				used[j] = true
+			}
+		}
+
+		cluster.Description = s.generateClusterDescription(cluster)
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters
+}
+
+// Helper functions
+func parseInt(s string) int {
+	// Simple integer parsing, ignoring errors for brevity
+	var result int
+	fmt.Sscanf(s, "%d", &result)
+	return result
+}
+
+func (s *Splitter) calculateFilePathSimilarity(files1, files2 []string) float64 {
+	if len(files1) == 0 || len(files2) == 0 {
+		return 0.0
+	}
+
+	// Check for overlapping files or similar paths
+	for _, f1 := range files1 {
+		for _, f2 := range files2 {
+			if f1 == f2 {
+				return 1.0
+			}
+			// Check if files are in the same directory
+			if filepath.Dir(f1) == filepath.Dir(f2) {
+				return 0.7
+			}
+			// Check if files have similar names
+			if strings.Contains(f1, strings.TrimSuffix(filepath.Base(f2), filepath.Ext(f2))) ||
+				strings.Contains(f2, strings.TrimSuffix(filepath.Base(f1), filepath.Ext(f1))) {
+				return 0.5
+			}
+		}
+	}
+
+	return 0.0
+}
+
+// calculateFunctionSimilarity scores how related two changes' touched
+// symbols are. When both sides have AST-derived Symbols, same-struct
+// methods (matching Receiver) count as related even if the specific
+// method differs, so e.g. (*Splitter).Foo and (*Splitter).Bar still pull
+// toward the same cluster. Falls back to exact-name matching on Functions
+// for changes the AST analyzer couldn't parse.
+func (s *Splitter) calculateFunctionSimilarity(a, b Change) float64 {
+	if len(a.Symbols) > 0 && len(b.Symbols) > 0 {
+		return symbolSimilarity(a.Symbols, b.Symbols)
+	}
+	return stringSetSimilarity(a.Functions, b.Functions)
+}
+
+func symbolSimilarity(syms1, syms2 []analyzer.Symbol) float64 {
+	common := 0
+	for _, s1 := range syms1 {
+		for _, s2 := range syms2 {
+			if s1.Name == s2.Name && s1.Receiver == s2.Receiver {
+				common++
+				break
+			}
+		}
+	}
+	if common > 0 {
+		return float64(common) / float64(max(len(syms1), len(syms2)))
+	}
+
+	for _, s1 := range syms1 {
+		if s1.Receiver == "" {
+			continue
+		}
+		for _, s2 := range syms2 {
+			if s2.Receiver == s1.Receiver {
+				return 0.5
+			}
+		}
+	}
+	return 0.0
+}
+
+func stringSetSimilarity(items1, items2 []string) float64 {
+	if len(items1) == 0 || len(items2) == 0 {
+		return 0.0
+	}
+
+	common := 0
+	for _, i1 := range items1 {
+		for _, i2 := range items2 {
+			if i1 == i2 {
+				common++
+				break
+			}
+		}
+	}
+
+	return float64(common) / float64(max(len(items1), len(items2)))
+}
+
+func (s *Splitter) calculateContentSimilarity(hunks1, hunks2 []Hunk) float64 {
+	// Simple keyword-based similarity
+	keywords1 := s.extractKeywords(hunks1)
+	keywords2 := s.extractKeywords(hunks2)
+
+	if len(keywords1) == 0 || len(keywords2) == 0 {
+		return 0.0
+	}
+
+	common := 0
+	for kw1 := range keywords1 {
+		if keywords2[kw1] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(max(len(keywords1), len(keywords2)))
+}
+
+func (s *Splitter) extractKeywords(hunks []Hunk) map[string]bool {
+	keywords := make(map[string]bool)
+
+	for _, hunk := range hunks {
+		lines := strings.Split(hunk.Content, "\n")
+		for _, line := range lines {
+			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+				content := strings.TrimPrefix(strings.TrimPrefix(line, "+"), "-")
+				words := strings.Fields(content)
+				for _, word := range words {
+					// Simple keyword extraction
+					word = strings.Trim(word, "(){}[].,;:")
+					if len(word) > 3 && !isCommonWord(word) {
+						keywords[strings.ToLower(word)] = true
+					}
+				}
+			}
+		}
+	}
+
+	return keywords
+}
+
+func (s *Splitter) mergeClusters(clusters []Cluster, maxClusters int) []Cluster {
+	if len(clusters) <= maxClusters {
+		return clusters
+	}
+
+	// Simple strategy: merge smallest clusters first
+	for len(clusters) > maxClusters {
+		// Find two smallest clusters
+		minIdx1, minIdx2 := 0, 1
+		minSize := len(clusters[0].Changes) + len(clusters[1].Changes)
+
+		for i := 0; i < len(clusters); i++ {
+			for j := i + 1; j < len(clusters); j++ {
+				size := len(clusters[i].Changes) + len(clusters[j].Changes)
+				if size < minSize {
+					minIdx1, minIdx2 = i, j
+					minSize = size
+				}
+			}
+		}
+
+		// Merge clusters
+		merged := Cluster{
+			Changes: append(clusters[minIdx1].Changes, clusters[minIdx2].Changes...),
+			Score:   (clusters[minIdx1].Score + clusters[minIdx2].Score) / 2,
+		}
+		merged.Description = s.generateClusterDescription(merged)
+
+		// Remove old clusters and add merged one
+		newClusters := make([]Cluster, 0, len(clusters)-1)
+		for i, cluster := range clusters {
+			if i != minIdx1 && i != minIdx2 {
+				newClusters = append(newClusters, cluster)
+			}
+		}
+		newClusters = append(newClusters, merged)
+		clusters = newClusters
+	}
+
+	return clusters
+}
+
+func (s *Splitter) generateClusterDescription(cluster Cluster) string {
+	if len(cluster.Changes) == 1 {
+		change := cluster.Changes[0]
+		base := singleChangeDescription(change)
+		if note := blameNote(change); note != "" {
+			return base + " (" + note + ")"
+		}
+		return base
+	}
+
+	// Multiple changes
+	if allSameKind(cluster.Changes, "generated") {
+		return "Regenerate generated files"
+	}
+	if allSameKind(cluster.Changes, "vendored") {
+		return "Update vendored dependencies"
+	}
+
+	allFiles := make(map[string]bool)
+	allFunctions := make(map[string]bool)
+
+	for _, change := range cluster.Changes {
+		for _, file := range change.Files {
+			allFiles[file] = true
+		}
+		for _, fn := range change.Functions {
+			allFunctions[fn] = true
+		}
+	}
+
+	var base string
+	if len(allFunctions) > 0 {
+		functions := make([]string, 0, len(allFunctions))
+		for fn := range allFunctions {
+			functions = append(functions, fn)
+		}
+		base = fmt.Sprintf("Update %s functions", strings.Join(functions, ", "))
+	} else {
+		base = fmt.Sprintf("Update %d files", len(allFiles))
+	}
+
+	if note := commonBlameNote(cluster.Changes); note != "" {
+		return base + " (" + note + ")"
+	}
+	return base
+}
+
+func singleChangeDescription(change Change) string {
+	switch change.Kind {
+	case "rename":
+		if from, ok := change.Metadata["renamed_from"].(string); ok {
+			return fmt.Sprintf("Rename %s to %s", from, strings.Join(change.Files, ", "))
+		}
+		return fmt.Sprintf("Rename %s", strings.Join(change.Files, ", "))
+	case "binary":
+		return fmt.Sprintf("Update binary file %s", strings.Join(change.Files, ", "))
+	case "generated":
+		return "Regenerate generated files"
+	case "vendored":
+		return "Update vendored dependencies"
+	}
+	if len(change.Functions) > 0 {
+		return fmt.Sprintf("Modify %s", strings.Join(change.Functions, ", "))
+	}
+	return fmt.Sprintf("Update %s", strings.Join(change.Files, ", "))
+}
+
+// blameNote surfaces who last touched the lines a change removed, e.g.
+// `reverts 3 line(s) from abc1234 "add retry loop"`, when
+// MultiCommit.UseBlame populated change.Metadata["blame"].
+func blameNote(change Change) string {
+	attributions, ok := change.Metadata["blame"].([]BlameAttribution)
+	if !ok || len(attributions) == 0 {
+		return ""
+	}
+
+	top := attributions[0]
+	if len(attributions) == 1 {
+		return fmt.Sprintf("reverts %d line(s) from %s %q", top.Lines, shortSHA(top.Commit), top.Subject)
+	}
+	return fmt.Sprintf("mostly reverts lines from %s %q", shortSHA(top.Commit), top.Subject)
+}
+
+// commonBlameNote reports when every change in a cluster's top blamed
+// commit is the same one -- a strong signal the whole cluster is a single
+// revert or refactor of that prior commit.
+func commonBlameNote(changes []Change) string {
+	var shared *BlameAttribution
+	for _, change := range changes {
+		attributions, ok := change.Metadata["blame"].([]BlameAttribution)
+		if !ok || len(attributions) == 0 {
+			return ""
+		}
+		if shared == nil {
+			shared = &attributions[0]
+		} else if attributions[0].Commit != shared.Commit {
+			return ""
+		}
+	}
+	if shared == nil {
+		return ""
+	}
+	return fmt.Sprintf("all modified lines last touched by %s %q (%s)", shortSHA(shared.Commit), shared.Subject, shared.Age)
+}
+
+func allSameKind(changes []Change, kind string) bool {
+	for _, c := range changes {
+		if c.Kind != kind {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper functions
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func isCommonWord(word string) bool {
+	commonWords := map[string]bool{
+		"the": true, "and": true, "for": true, "are": true, "but": true,
+		"not": true, "you": true, "all": true, "can": true, "had": true,
+		"her": true, "was": true, "one": true, "our": true, "out": true,
+		"day": true, "get": true, "has": true, "him": true, "his": true,
+		"how": true, "its": true, "new": true, "now": true, "old": true,
+		"see": true, "two": true, "who": true, "boy": true, "did": true,
+		"may": true, "put": true, "say": true, "she": true, "too": true,
+		"use": true, "var": true, "nil": true, "err": true, "int": true,
+	}
+	return commonWords[strings.ToLower(word)]
+}
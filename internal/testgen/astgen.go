@@ -0,0 +1,628 @@
+package testgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// buildTestFile renders pkg's functions into a single table-driven test
+// file, built as an *ast.File and rendered with go/format.Node rather than
+// string-concatenated source. When fuzzEnabled, it also emits a
+// FuzzX(f *testing.F) target -- seeded via FuzzCorpus -- for every function
+// eligible under fuzz's own min/max-params and exclude_types settings.
+func buildTestFile(pkg string, functions []FunctionInfo, fuzz config.Fuzz, fuzzEnabled bool) (TestFile, error) {
+	// An external (package pkg_test) file can only reach fn through its
+	// exported identifier; one unexported function in the batch, and
+	// "pkg.name(...)" either doesn't compile (undefined) or silently calls
+	// the wrong thing. So the whole file drops to an in-package test
+	// (package pkg, no call-site qualifier) the moment any selected
+	// function is unexported, rather than deciding this per function.
+	external := pkg != "main" && allExported(functions)
+	testPkgName := pkg + "_test"
+	if !external {
+		testPkgName = pkg
+		if pkg == "main" {
+			testPkgName = "main"
+		}
+	}
+
+	imports := map[string]string{}
+	corpus := map[string][]string{}
+	var decls []ast.Decl
+
+	for _, fn := range functions {
+		callPrefix := ""
+		if external && fn.pkg != nil {
+			callPrefix = fn.pkg.Name + "."
+			imports[fn.pkg.PkgPath] = fn.pkg.Name
+		}
+
+		decl, usesReflect, usesMath := buildTestFuncDecl(fn, callPrefix)
+		decls = append(decls, decl)
+
+		if usesReflect {
+			imports["reflect"] = "reflect"
+		}
+		if usesMath {
+			imports["math"] = "math"
+		}
+		if fn.pkg != nil {
+			collectFunctionImports(fn, fn.pkg.PkgPath, imports)
+		}
+
+		if fuzzEnabled && fuzzable(fn, fuzz) {
+			fuzzDecl, seeds, fuzzReflect, fuzzMath := buildFuzzFuncDecl(fn, callPrefix)
+			decls = append(decls, fuzzDecl)
+			if fuzzReflect {
+				imports["reflect"] = "reflect"
+			}
+			if fuzzMath {
+				imports["math"] = "math"
+			}
+			corpus[fuzzFuncName(fn)] = seeds
+		}
+	}
+
+	imports["testing"] = "testing"
+
+	file := &ast.File{
+		Name:  ast.NewIdent(testPkgName),
+		Decls: append([]ast.Decl{buildImportDecl(imports)}, decls...),
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), file); err != nil {
+		return TestFile{}, fmt.Errorf("rendering generated test file for package %s: %v", pkg, err)
+	}
+
+	importPaths := make([]string, 0, len(imports))
+	for path := range imports {
+		importPaths = append(importPaths, path)
+	}
+	sort.Strings(importPaths)
+
+	return TestFile{
+		Package:    testPkgName,
+		Imports:    importPaths,
+		Content:    buf.String(),
+		FuzzCorpus: corpus,
+	}, nil
+}
+
+// allExported reports whether every function in functions is exported, the
+// precondition for rendering them into an external (package pkg_test) test
+// file -- see buildTestFile.
+func allExported(functions []FunctionInfo) bool {
+	for _, fn := range functions {
+		if !fn.IsExported {
+			return false
+		}
+	}
+	return true
+}
+
+// collectFunctionImports records every package fn's parameters, returns and
+// receiver reference, other than withinPkgPath, into out.
+func collectFunctionImports(fn FunctionInfo, withinPkgPath string, out map[string]string) {
+	if fn.Receiver != nil && fn.Receiver.goType != nil {
+		collectImports(fn.Receiver.goType, withinPkgPath, out)
+	}
+	for _, p := range fn.Parameters {
+		if p.goType != nil {
+			collectImports(p.goType, withinPkgPath, out)
+		}
+	}
+	for _, r := range fn.Returns {
+		if r.goType != nil {
+			collectImports(r.goType, withinPkgPath, out)
+		}
+	}
+}
+
+func buildImportDecl(imports map[string]string) ast.Decl {
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	decl := &ast.GenDecl{Tok: token.IMPORT}
+	for _, path := range paths {
+		decl.Specs = append(decl.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)},
+		})
+	}
+	return decl
+}
+
+// buildTestFuncDecl builds "func Test<Name>(t *testing.T) {...}" for fn. If
+// fn's type couldn't be resolved (fn.pkg is nil), it emits an honest
+// t.Skip scaffold instead of guessing at untyped zero values.
+func buildTestFuncDecl(fn FunctionInfo, callPrefix string) (decl *ast.FuncDecl, usesReflect, usesMath bool) {
+	name := testFuncName(fn)
+
+	if fn.pkg == nil {
+		return skipTestFuncDecl(name, fn.Name), false, false
+	}
+
+	q := types.RelativeTo(fn.pkg.Types)
+
+	var body []ast.Stmt
+	if fn.Receiver != nil {
+		body = append(body, buildReceiverInit(fn, q, callPrefix))
+	}
+
+	fields, rows, usesReflect2, usesMath2 := buildCases(fn, q)
+	usesReflect, usesMath = usesReflect2, usesMath2
+
+	body = append(body, &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("cases")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: &ast.StructType{Fields: &ast.FieldList{List: fields}}},
+			Elts: rows,
+		}},
+	})
+
+	runBody, runReflect := buildRunBody(fn, callPrefix)
+	usesReflect = usesReflect || runReflect
+
+	body = append(body, &ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("tc"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("cases"),
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Run")},
+				Args: []ast.Expr{
+					&ast.SelectorExpr{X: ast.NewIdent("tc"), Sel: ast.NewIdent("name")},
+					&ast.FuncLit{
+						Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+							{Names: []*ast.Ident{ast.NewIdent("t")}, Type: &ast.StarExpr{X: rawIdent("testing.T")}},
+						}}},
+						Body: &ast.BlockStmt{List: runBody},
+					},
+				},
+			}},
+		}},
+	})
+
+	decl = &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{ast.NewIdent("t")}, Type: &ast.StarExpr{X: rawIdent("testing.T")}},
+		}}},
+		Body: &ast.BlockStmt{List: body},
+	}
+	return decl, usesReflect, usesMath
+}
+
+func skipTestFuncDecl(testName, fnName string) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(testName),
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{ast.NewIdent("t")}, Type: &ast.StarExpr{X: rawIdent("testing.T")}},
+		}}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Skip")},
+				Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(
+					"testgen couldn't resolve types for " + fnName + "; fill in this test by hand",
+				)}},
+			}},
+		}},
+	}
+}
+
+// testFuncName derives a valid, collision-resistant TestXxx name: "Xxx"
+// must not start with a lowercase letter for `go test` to discover it, so
+// the first rune of fn.Name (and of its receiver type, if any) is upper-
+// cased regardless of the original function's exported-ness.
+func testFuncName(fn FunctionInfo) string {
+	base := fn.Name
+	if fn.Receiver != nil {
+		base = typeIdentifier(fn.Receiver.Type) + "_" + fn.Name
+	}
+	r := []rune(base)
+	if len(r) == 0 {
+		return "Test"
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return "Test" + string(r)
+}
+
+// typeIdentifier strips a leading "*" from a type string, for embedding a
+// receiver's type name in a generated test name.
+func typeIdentifier(typeStr string) string {
+	for len(typeStr) > 0 && typeStr[0] == '*' {
+		typeStr = typeStr[1:]
+	}
+	return typeStr
+}
+
+// buildReceiverInit builds "sut := <ctor-call-or-zero-value>" for a method
+// under test, preferring a discoverable constructor over a bare zero value
+// so calling the method doesn't immediately dereference a nil receiver.
+func buildReceiverInit(fn FunctionInfo, q types.Qualifier, callPrefix string) ast.Stmt {
+	recv := fn.Receiver
+	var value ast.Expr
+
+	if ctor, ok := findConstructor(fn.pkg, recv.goType); ok {
+		sig := ctor.Type().(*types.Signature)
+		var args []ast.Expr
+		for i := 0; i < sig.Params().Len(); i++ {
+			args = append(args, zeroExpr(sig.Params().At(i).Type(), q))
+		}
+		value = &ast.CallExpr{Fun: rawIdent(callPrefix + ctor.Name()), Args: args}
+	} else if named, ok := underlyingNamed(recv.goType); ok {
+		lit := &ast.CompositeLit{Type: rawIdent(callPrefix + named.Obj().Name())}
+		if _, isPtr := recv.goType.(*types.Pointer); isPtr {
+			value = &ast.UnaryExpr{Op: token.AND, X: lit}
+		} else {
+			value = lit
+		}
+	} else {
+		value = zeroExpr(recv.goType, q)
+	}
+
+	return &ast.AssignStmt{Lhs: []ast.Expr{ast.NewIdent("sut")}, Tok: token.DEFINE, Rhs: []ast.Expr{value}}
+}
+
+// isErrorType reports whether t is the predeclared "error" interface.
+func isErrorType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() == nil && named.Obj().Name() == "error"
+}
+
+// buildCases builds the `[]struct{...}{...}` field list and row literals
+// for fn: a "zero values" row, plus a boundary row per numeric/string
+// parameter. It does not fabricate an error-triggering row -- see the
+// comment at the end of the function body for why -- and, for the same
+// reason, it does not fabricate a want/wantN field either: testgen only
+// has fn's signature, not its semantics, so it has no way to know what a
+// given input should produce. Asserting zeroExpr(returnType) as the
+// expected value would make every row pass only for functions that happen
+// to return their zero value, and fail the rest of the time -- see
+// buildRunBody for what's emitted instead.
+func buildCases(fn FunctionInfo, q types.Qualifier) (fields []*ast.Field, rows []ast.Expr, usesReflect, usesMath bool) {
+	fields = append(fields, &ast.Field{Names: []*ast.Ident{ast.NewIdent("name")}, Type: ast.NewIdent("string")})
+	for _, p := range fn.Parameters {
+		fields = append(fields, &ast.Field{Names: []*ast.Ident{ast.NewIdent(p.Name)}, Type: rawIdent(p.Type)})
+	}
+
+	_, hasError := splitReturns(fn.Returns)
+	if hasError {
+		fields = append(fields, &ast.Field{Names: []*ast.Ident{ast.NewIdent("wantErr")}, Type: ast.NewIdent("bool")})
+	}
+
+	zeroRow := func(name string, overrides map[string]ast.Expr, wantErr bool) ast.Expr {
+		kvs := []ast.Expr{&ast.KeyValueExpr{Key: ast.NewIdent("name"), Value: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(name)}}}
+		for _, p := range fn.Parameters {
+			v := zeroExpr(p.goType, q)
+			if override, ok := overrides[p.Name]; ok {
+				v = override
+			}
+			kvs = append(kvs, &ast.KeyValueExpr{Key: ast.NewIdent(p.Name), Value: v})
+		}
+		if hasError {
+			want := ast.NewIdent("false")
+			if wantErr {
+				want = ast.NewIdent("true")
+			}
+			kvs = append(kvs, &ast.KeyValueExpr{Key: ast.NewIdent("wantErr"), Value: want})
+		}
+		return &ast.CompositeLit{Elts: kvs}
+	}
+
+	rows = append(rows, zeroRow("zero values", nil, false))
+
+	for _, p := range fn.Parameters {
+		if min, max, ok := numericBoundaries(p.goType); ok {
+			usesMath = true
+			rows = append(rows, zeroRow(p.Name+" minimum", map[string]ast.Expr{p.Name: min}, false))
+			rows = append(rows, zeroRow(p.Name+" maximum", map[string]ast.Expr{p.Name: max}, false))
+			continue
+		}
+		if isStringType(p.goType) {
+			rows = append(rows, zeroRow(p.Name+" long string", map[string]ast.Expr{p.Name: longStringLit()}, false))
+			rows = append(rows, zeroRow(p.Name+" unicode", map[string]ast.Expr{p.Name: unicodeStringLit()}, false))
+		}
+	}
+
+	// No error-input-deriving row is generated here: without real argument
+	// synthesis we'd have to guess which inputs make fn fail, and a wrong
+	// guess (e.g. zero values, which often don't error) produces a subtest
+	// that's guaranteed to fail rather than one that's merely incomplete.
+	// hasError still gets the "zero values" row's wantErr:false case above;
+	// a human fills in the actual error-triggering case by hand.
+
+	return fields, rows, usesReflect, usesMath
+}
+
+// splitReturns separates fn's non-error returns from its (at most one)
+// error return.
+func splitReturns(returns []ReturnValue) (values []ReturnValue, hasError bool) {
+	for _, r := range returns {
+		if r.goType != nil && isErrorType(r.goType) {
+			hasError = true
+			continue
+		}
+		values = append(values, r)
+	}
+	return values, hasError
+}
+
+// buildRunBody builds the body of the t.Run subtest closure: the call
+// itself, an error-nilness check against tc.wantErr, and -- since testgen
+// has no way to know what fn should return for a given input, see
+// buildCases -- a t.Logf of whatever it actually returned rather than a
+// fabricated equality assertion. A human replaces that Logf with a real
+// check once they've looked at the logged value.
+func buildRunBody(fn FunctionInfo, callPrefix string) (stmts []ast.Stmt, usesReflect bool) {
+	valueReturns, hasError := splitReturns(fn.Returns)
+
+	var lhs []ast.Expr
+	valueVar := func(i int) string {
+		if len(valueReturns) == 1 {
+			return "got"
+		}
+		return fmt.Sprintf("got%d", i)
+	}
+	vi := 0
+	for _, r := range fn.Returns {
+		if r.goType != nil && isErrorType(r.goType) {
+			lhs = append(lhs, ast.NewIdent("err"))
+			continue
+		}
+		lhs = append(lhs, ast.NewIdent(valueVar(vi)))
+		vi++
+	}
+
+	var args []ast.Expr
+	for _, p := range fn.Parameters {
+		args = append(args, &ast.SelectorExpr{X: ast.NewIdent("tc"), Sel: ast.NewIdent(p.Name)})
+	}
+
+	var fun ast.Expr
+	if fn.Receiver != nil {
+		fun = &ast.SelectorExpr{X: ast.NewIdent("sut"), Sel: ast.NewIdent(fn.Name)}
+	} else {
+		fun = rawIdent(callPrefix + fn.Name)
+	}
+	call := &ast.CallExpr{Fun: fun, Args: args}
+
+	if len(lhs) == 0 {
+		stmts = append(stmts, &ast.ExprStmt{X: call})
+	} else {
+		stmts = append(stmts, &ast.AssignStmt{Lhs: lhs, Tok: token.DEFINE, Rhs: []ast.Expr{call}})
+	}
+
+	if hasError {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.BinaryExpr{X: ast.NewIdent("err"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+				Op: token.NEQ,
+				Y:  &ast.SelectorExpr{X: ast.NewIdent("tc"), Sel: ast.NewIdent("wantErr")},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Fatalf")},
+					Args: []ast.Expr{
+						&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(fn.Name + "() error = %v, wantErr %v")},
+						ast.NewIdent("err"),
+						&ast.SelectorExpr{X: ast.NewIdent("tc"), Sel: ast.NewIdent("wantErr")},
+					},
+				}},
+			}},
+		})
+	}
+
+	if len(valueReturns) > 0 {
+		format := fn.Name + "() = " + strings.Repeat("%v, ", len(valueReturns))
+		format = strings.TrimSuffix(format, ", ") + " -- no expected value available; replace this Logf with a real assertion"
+		logArgs := []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(format)}}
+		for i := range valueReturns {
+			logArgs = append(logArgs, ast.NewIdent(valueVar(i)))
+		}
+		logStmt := &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Logf")},
+			Args: logArgs,
+		}}
+
+		if hasError {
+			stmts = append(stmts, &ast.IfStmt{
+				Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.SelectorExpr{X: ast.NewIdent("tc"), Sel: ast.NewIdent("wantErr")}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{logStmt}},
+			})
+		} else {
+			stmts = append(stmts, logStmt)
+		}
+	}
+
+	return stmts, usesReflect
+}
+
+// fuzzFuncName mirrors testFuncName's capitalization rules under the
+// "Fuzz" prefix `go test` requires for native fuzz targets.
+func fuzzFuncName(fn FunctionInfo) string {
+	base := fn.Name
+	if fn.Receiver != nil {
+		base = typeIdentifier(fn.Receiver.Type) + "_" + fn.Name
+	}
+	r := []rune(base)
+	if len(r) == 0 {
+		return "Fuzz"
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return "Fuzz" + string(r)
+}
+
+// buildFuzzFuncDecl builds "func FuzzX(f *testing.F) {...}" for fn: f.Add
+// calls seeding the corpus with the same boundary values buildCases rows
+// on, followed by an f.Fuzz closure that calls fn and asserts it doesn't
+// panic and that a non-nil error implies a zero-value result. seeds is
+// returned alongside so the caller can also persist them as on-disk corpus
+// files under testdata/fuzz/FuzzX/.
+func buildFuzzFuncDecl(fn FunctionInfo, callPrefix string) (decl *ast.FuncDecl, seeds []string, usesReflect, usesMath bool) {
+	name := fuzzFuncName(fn)
+	q := types.RelativeTo(fn.pkg.Types)
+
+	var body []ast.Stmt
+	if fn.Receiver != nil {
+		body = append(body, buildReceiverInit(fn, q, callPrefix))
+	}
+
+	rows, corpusText, rowsUseMath := fuzzSeeds(fn, q)
+	seeds = corpusText
+	usesMath = rowsUseMath
+	for _, row := range rows {
+		body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: ast.NewIdent("f"), Sel: ast.NewIdent("Add")},
+			Args: row,
+		}})
+	}
+
+	fuzzParams := []*ast.Field{{Names: []*ast.Ident{ast.NewIdent("t")}, Type: &ast.StarExpr{X: rawIdent("testing.T")}}}
+	for _, p := range fn.Parameters {
+		fuzzParams = append(fuzzParams, &ast.Field{Names: []*ast.Ident{ast.NewIdent(p.Name)}, Type: rawIdent(p.Type)})
+	}
+
+	runBody, runReflect := buildFuzzRunBody(fn, callPrefix)
+	usesReflect = runReflect
+
+	body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: ast.NewIdent("f"), Sel: ast.NewIdent("Fuzz")},
+		Args: []ast.Expr{&ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{List: fuzzParams}},
+			Body: &ast.BlockStmt{List: runBody},
+		}},
+	}})
+
+	decl = &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{ast.NewIdent("f")}, Type: &ast.StarExpr{X: rawIdent("testing.F")}},
+		}}},
+		Body: &ast.BlockStmt{List: body},
+	}
+	return decl, seeds, usesReflect, usesMath
+}
+
+// buildFuzzRunBody builds the body of f.Fuzz's closure: a deferred recover
+// that turns a panic into a readable t.Fatalf instead of a raw crasher
+// dump, the call itself, and -- when fn returns (T, error) -- a check that
+// a non-nil error came with a zero-value T.
+func buildFuzzRunBody(fn FunctionInfo, callPrefix string) (stmts []ast.Stmt, usesReflect bool) {
+	stmts = append(stmts, &ast.DeferStmt{Call: &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{Lhs: []ast.Expr{ast.NewIdent("r")}, Tok: token.DEFINE, Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("recover")}}},
+					Cond: &ast.BinaryExpr{X: ast.NewIdent("r"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+					Body: &ast.BlockStmt{List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Fatalf")},
+							Args: []ast.Expr{
+								&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(fn.Name + "() panicked: %v")},
+								ast.NewIdent("r"),
+							},
+						}},
+					}},
+				},
+			}},
+		},
+	}})
+
+	valueReturns, hasError := splitReturns(fn.Returns)
+
+	var lhs []ast.Expr
+	valueVar := func(i int) string {
+		if len(valueReturns) == 1 {
+			return "got"
+		}
+		return fmt.Sprintf("got%d", i)
+	}
+	vi := 0
+	for _, r := range fn.Returns {
+		if r.goType != nil && isErrorType(r.goType) {
+			lhs = append(lhs, ast.NewIdent("err"))
+			continue
+		}
+		lhs = append(lhs, ast.NewIdent(valueVar(vi)))
+		vi++
+	}
+
+	var args []ast.Expr
+	for _, p := range fn.Parameters {
+		args = append(args, ast.NewIdent(p.Name))
+	}
+
+	var fun ast.Expr
+	if fn.Receiver != nil {
+		fun = &ast.SelectorExpr{X: ast.NewIdent("sut"), Sel: ast.NewIdent(fn.Name)}
+	} else {
+		fun = rawIdent(callPrefix + fn.Name)
+	}
+	call := &ast.CallExpr{Fun: fun, Args: args}
+
+	if len(lhs) == 0 {
+		stmts = append(stmts, &ast.ExprStmt{X: call})
+		return stmts, usesReflect
+	}
+	stmts = append(stmts, &ast.AssignStmt{Lhs: lhs, Tok: token.DEFINE, Rhs: []ast.Expr{call}})
+
+	if !hasError || len(valueReturns) == 0 {
+		return stmts, usesReflect
+	}
+
+	q := types.RelativeTo(fn.pkg.Types)
+	var zeroChecks []ast.Stmt
+	for i, r := range valueReturns {
+		got := valueVar(i)
+		zeroVal := zeroExpr(r.goType, q)
+
+		var cond ast.Expr
+		if needsDeepEqual(r.goType) {
+			usesReflect = true
+			cond = &ast.UnaryExpr{Op: token.NOT, X: &ast.CallExpr{
+				Fun:  rawIdent("reflect.DeepEqual"),
+				Args: []ast.Expr{ast.NewIdent(got), zeroVal},
+			}}
+		} else {
+			cond = &ast.BinaryExpr{X: ast.NewIdent(got), Op: token.NEQ, Y: zeroVal}
+		}
+
+		zeroChecks = append(zeroChecks, &ast.IfStmt{
+			Cond: cond,
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{X: ast.NewIdent("t"), Sel: ast.NewIdent("Errorf")},
+					Args: []ast.Expr{
+						&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(fn.Name + "() returned a non-zero result alongside a non-nil error: %v, %v")},
+						ast.NewIdent(got),
+						ast.NewIdent("err"),
+					},
+				}},
+			}},
+		})
+	}
+
+	stmts = append(stmts, &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: ast.NewIdent("err"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{List: zeroChecks},
+	})
+
+	return stmts, usesReflect
+}
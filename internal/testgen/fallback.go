@@ -0,0 +1,130 @@
+package testgen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+)
+
+// analyzeFunctionsFromAST is analyzeFunctionsInFile's fallback when loadFile
+// can't resolve filename's package through go/packages. It yields the same
+// FunctionInfo shape but with goType left nil on every Parameter/
+// ReturnValue, so buildTestFile seeds untyped placeholders instead of
+// real zero/boundary values.
+func (tg *TestGenerator) analyzeFunctionsFromAST(filename string, changedLines map[int]bool) ([]FunctionInfo, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var functions []FunctionInfo
+	ast.Inspect(node, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok {
+			return true
+		}
+		if !fn.Name.IsExported() && !tg.shouldIncludePrivateFunction(fn.Name.Name) {
+			return true
+		}
+
+		start := fset.Position(fn.Pos()).Line
+		end := fset.Position(fn.End()).Line
+		if !tg.isFunctionChanged(start, end, changedLines) {
+			return true
+		}
+
+		info := FunctionInfo{
+			Name:       fn.Name.Name,
+			Package:    node.Name.Name,
+			File:       filename,
+			IsExported: fn.Name.IsExported(),
+			Metadata:   make(map[string]interface{}),
+		}
+		functions = append(functions, tg.extractFunctionInfoFromAST(fn, info))
+		return true
+	})
+
+	return functions, nil
+}
+
+// extractFunctionInfoFromAST fills info's Parameters/Returns/Receiver/
+// Comments straight off fn's AST shape, untyped, for the cases where no
+// *types.Signature could be resolved.
+func (tg *TestGenerator) extractFunctionInfoFromAST(fn *ast.FuncDecl, info FunctionInfo) FunctionInfo {
+	if fn.Doc != nil && info.Comments == "" {
+		info.Comments = fn.Doc.Text()
+	}
+
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		field := fn.Recv.List[0]
+		name := "recv"
+		if len(field.Names) > 0 {
+			name = field.Names[0].Name
+		}
+		info.Receiver = &Parameter{Name: name, Type: astTypeString(field.Type)}
+	}
+
+	if fn.Type.Params != nil {
+		i := 0
+		for _, field := range fn.Type.Params.List {
+			paramType := astTypeString(field.Type)
+			if len(field.Names) == 0 {
+				info.Parameters = append(info.Parameters, Parameter{Name: fmtArgName(i), Type: paramType})
+				i++
+				continue
+			}
+			for _, name := range field.Names {
+				info.Parameters = append(info.Parameters, Parameter{Name: name.Name, Type: paramType})
+				i++
+			}
+		}
+	}
+
+	if fn.Type.Results != nil {
+		for i, field := range fn.Type.Results.List {
+			returnType := astTypeString(field.Type)
+			if len(field.Names) > 0 {
+				for _, name := range field.Names {
+					info.Returns = append(info.Returns, ReturnValue{Name: name.Name, Type: returnType})
+				}
+			} else {
+				info.Returns = append(info.Returns, ReturnValue{Name: fmtRetName(i), Type: returnType})
+			}
+		}
+	}
+
+	return info
+}
+
+func fmtArgName(i int) string { return "arg" + strconv.Itoa(i) }
+func fmtRetName(i int) string { return "ret" + strconv.Itoa(i) }
+
+// astTypeString renders expr's textual type without resolved type info --
+// good enough for the AST-only fallback path, which can't build real
+// zero/boundary values anyway.
+func astTypeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return astTypeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + astTypeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + astTypeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + astTypeString(t.Key) + "]" + astTypeString(t.Value)
+	case *ast.InterfaceType:
+		return "interface{}"
+	default:
+		return "interface{}"
+	}
+}
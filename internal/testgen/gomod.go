@@ -0,0 +1,40 @@
+package testgen
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// goDirectiveRe matches go.mod's "go X.Y" directive line. It deliberately
+// doesn't anchor on "^" since the directive can be indented in a
+// multi-module workspace file, though go.mod itself never indents it.
+var goDirectiveRe = regexp.MustCompile(`(?m)^\s*go\s+(\d+)\.(\d+)`)
+
+// goModSupportsFuzzing reports whether the repo's go.mod declares Go 1.18
+// or newer, the version native fuzzing (testing.F) requires. A missing or
+// unparsable go.mod is treated as unsupported rather than assumed fine, so
+// generation fails closed instead of emitting fuzz targets the toolchain
+// can't run.
+func goModSupportsFuzzing() bool {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return false
+	}
+
+	m := goDirectiveRe.FindSubmatch(data)
+	if m == nil {
+		return false
+	}
+
+	major, err := strconv.Atoi(string(m[1]))
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(string(m[2]))
+	if err != nil {
+		return false
+	}
+
+	return major > 1 || (major == 1 && minor >= 18)
+}
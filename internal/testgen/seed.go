@@ -0,0 +1,316 @@
+package testgen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/Harri200191/gitmind/internal/config"
+)
+
+// rawIdent wraps a piece of already-valid Go source text (a type string
+// from types.TypeString, a qualified constant like "math.MaxInt8") as an
+// *ast.Ident so it can be dropped straight into a composite literal's Type
+// field or a value position. go/printer emits an Ident's Name verbatim
+// without re-validating it, so this is a deliberate shortcut around
+// building a fully general type-expression AST (arrays, maps, generics,
+// ...) for text we already have the correct rendering of.
+func rawIdent(text string) ast.Expr {
+	return ast.NewIdent(text)
+}
+
+// intBoundaries maps a basic integer/float kind to its {min, max} constant
+// expressions (as raw text -- see rawIdent), used to seed boundary test
+// cases for that parameter's type.
+var intBoundaries = map[types.BasicKind][2]string{
+	types.Int8:    {"math.MinInt8", "math.MaxInt8"},
+	types.Int16:   {"math.MinInt16", "math.MaxInt16"},
+	types.Int32:   {"math.MinInt32", "math.MaxInt32"},
+	types.Int64:   {"math.MinInt64", "math.MaxInt64"},
+	types.Int:     {"math.MinInt", "math.MaxInt"},
+	types.Uint8:   {"0", "math.MaxUint8"},
+	types.Uint16:  {"0", "math.MaxUint16"},
+	types.Uint32:  {"0", "math.MaxUint32"},
+	types.Uint64:  {"0", "math.MaxUint64"},
+	types.Uint:    {"0", "math.MaxUint"},
+	types.Uintptr: {"0", "math.MaxUint64"},
+	types.Float32: {"-math.MaxFloat32", "math.MaxFloat32"},
+	types.Float64: {"-math.MaxFloat64", "math.MaxFloat64"},
+}
+
+// zeroExpr builds the zero-value expression for t: false/""/0 for a basic
+// kind, nil for a pointer/slice/map/chan/interface/func (including error),
+// and a composite literal for a named struct or array.
+func zeroExpr(t types.Type, q types.Qualifier) ast.Expr {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return ast.NewIdent("false")
+		case u.Info()&types.IsString != 0:
+			return &ast.BasicLit{Kind: token.STRING, Value: `""`}
+		case u.Info()&types.IsNumeric != 0:
+			return &ast.BasicLit{Kind: token.INT, Value: "0"}
+		default:
+			return ast.NewIdent("nil")
+		}
+	case *types.Struct, *types.Array:
+		return &ast.CompositeLit{Type: rawIdent(types.TypeString(t, q))}
+	default:
+		return ast.NewIdent("nil")
+	}
+}
+
+// numericBoundaries returns t's min/max boundary expressions, or ok=false
+// if t isn't a kind intBoundaries covers.
+func numericBoundaries(t types.Type) (min, max ast.Expr, ok bool) {
+	basic, isBasic := t.Underlying().(*types.Basic)
+	if !isBasic {
+		return nil, nil, false
+	}
+	bounds, ok := intBoundaries[basic.Kind()]
+	if !ok {
+		return nil, nil, false
+	}
+	return rawIdent(bounds[0]), rawIdent(bounds[1]), true
+}
+
+// isStringType reports whether t's underlying type is a string.
+func isStringType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// longStringLit and unicodeStringLit seed the "long string"/"unicode"
+// boundary cases the request asks for explicitly.
+func longStringLit() ast.Expr {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(strings.Repeat("a", 256))}
+}
+
+func unicodeStringLit() ast.Expr {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote("héllo wörld 网页 🚀")}
+}
+
+// needsDeepEqual reports whether comparing two values of t should go
+// through reflect.DeepEqual rather than ==: true for anything whose
+// underlying type isn't a plain comparable basic kind (structs, slices,
+// maps, pointers to them, ...).
+func needsDeepEqual(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Basic:
+		return false
+	default:
+		return true
+	}
+}
+
+// collectImports walks t (recursing into pointer/slice/array/map/chan
+// element types and named-type generic arguments) and records every
+// distinct package it references, other than withinPkgPath, into out
+// (import path -> package name).
+func collectImports(t types.Type, withinPkgPath string, out map[string]string) {
+	switch tt := t.(type) {
+	case *types.Named:
+		if obj := tt.Obj(); obj.Pkg() != nil && obj.Pkg().Path() != withinPkgPath {
+			out[obj.Pkg().Path()] = obj.Pkg().Name()
+		}
+		if targs := tt.TypeArgs(); targs != nil {
+			for i := 0; i < targs.Len(); i++ {
+				collectImports(targs.At(i), withinPkgPath, out)
+			}
+		}
+	case *types.Pointer:
+		collectImports(tt.Elem(), withinPkgPath, out)
+	case *types.Slice:
+		collectImports(tt.Elem(), withinPkgPath, out)
+	case *types.Array:
+		collectImports(tt.Elem(), withinPkgPath, out)
+	case *types.Map:
+		collectImports(tt.Key(), withinPkgPath, out)
+		collectImports(tt.Elem(), withinPkgPath, out)
+	case *types.Chan:
+		collectImports(tt.Elem(), withinPkgPath, out)
+	}
+}
+
+// underlyingNamed unwraps a single pointer indirection and reports t's
+// *types.Named, if any -- e.g. for matching a receiver type against a
+// candidate constructor's result type.
+func underlyingNamed(t types.Type) (*types.Named, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	return n, ok
+}
+
+// fuzzSeeds builds fn's f.Add(...) argument rows -- the same zero-value-
+// plus-boundary rows buildCases uses for its table -- alongside their
+// "go test fuzz v1" corpus-file encodings for WriteTestFiles to persist
+// under testdata/fuzz/FuzzX/.
+func fuzzSeeds(fn FunctionInfo, q types.Qualifier) (rows [][]ast.Expr, corpus []string, usesMath bool) {
+	row := func(overrides map[string]ast.Expr) []ast.Expr {
+		var args []ast.Expr
+		for _, p := range fn.Parameters {
+			v := zeroExpr(p.goType, q)
+			if o, ok := overrides[p.Name]; ok {
+				v = o
+			}
+			args = append(args, v)
+		}
+		return args
+	}
+	add := func(overrides map[string]ast.Expr) {
+		r := row(overrides)
+		rows = append(rows, r)
+		corpus = append(corpus, encodeCorpus(fn.Parameters, r))
+	}
+
+	add(nil)
+	for _, p := range fn.Parameters {
+		if min, max, ok := numericBoundaries(p.goType); ok {
+			usesMath = true
+			add(map[string]ast.Expr{p.Name: min})
+			add(map[string]ast.Expr{p.Name: max})
+			continue
+		}
+		if isStringType(p.goType) {
+			add(map[string]ast.Expr{p.Name: longStringLit()})
+			add(map[string]ast.Expr{p.Name: unicodeStringLit()})
+		}
+	}
+	return rows, corpus, usesMath
+}
+
+// encodeCorpus renders one seed row in the "go test fuzz v1" format
+// `go test -fuzz` reads from a FuzzX corpus directory, e.g.
+// `go test fuzz v1\nstring("hello")\nint32(7)\n`.
+func encodeCorpus(params []Parameter, args []ast.Expr) string {
+	var b strings.Builder
+	b.WriteString("go test fuzz v1\n")
+	for i, arg := range args {
+		var buf bytes.Buffer
+		format.Node(&buf, token.NewFileSet(), arg)
+		b.WriteString(corpusTypeName(params[i].goType))
+		b.WriteByte('(')
+		b.WriteString(buf.String())
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+// corpusTypeName returns t's name the way the fuzz corpus format spells it
+// (e.g. "int32", "[]byte"), or "" if t isn't a type testing.F.Add accepts.
+func corpusTypeName(t types.Type) string {
+	if basic, ok := t.Underlying().(*types.Basic); ok {
+		switch basic.Kind() {
+		case types.Bool:
+			return "bool"
+		case types.String:
+			return "string"
+		case types.Int:
+			return "int"
+		case types.Int8:
+			return "int8"
+		case types.Int16:
+			return "int16"
+		case types.Int32:
+			return "int32"
+		case types.Int64:
+			return "int64"
+		case types.Uint:
+			return "uint"
+		case types.Uint8:
+			return "uint8"
+		case types.Uint16:
+			return "uint16"
+		case types.Uint32:
+			return "uint32"
+		case types.Uint64:
+			return "uint64"
+		case types.Float32:
+			return "float32"
+		case types.Float64:
+			return "float64"
+		}
+	}
+	if slice, ok := t.Underlying().(*types.Slice); ok {
+		if b, ok := slice.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			return "[]byte"
+		}
+	}
+	return ""
+}
+
+// fuzzableParamType reports whether t is one of the types testing.F.Add
+// accepts: string, []byte, bool, or an integer or float kind.
+func fuzzableParamType(t types.Type) bool {
+	return t != nil && corpusTypeName(t) != ""
+}
+
+// fuzzable reports whether fn is eligible for a FuzzX target under cfg:
+// its types must have resolved, its parameter count must fall within
+// [MinParams, MaxParams], and none of its parameters may be a type
+// cfg.ExcludeTypes names or testing.F.Add doesn't support.
+func fuzzable(fn FunctionInfo, cfg config.Fuzz) bool {
+	if fn.pkg == nil {
+		return false
+	}
+	if n := len(fn.Parameters); n < cfg.MinParams || n > cfg.MaxParams {
+		return false
+	}
+	for _, p := range fn.Parameters {
+		if !fuzzableParamType(p.goType) {
+			return false
+		}
+		for _, excluded := range cfg.ExcludeTypes {
+			if p.Type == excluded {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// findConstructor looks for an exported package-level function that looks
+// like recv's constructor -- conventionally "New"+<type name>, or just
+// "New" when that's what the package calls its sole constructor -- and
+// whose first result is recv or *recv. Returns false if none matches, in
+// which case the caller falls back to recv's zero value.
+func findConstructor(pkg *packages.Package, recv types.Type) (*types.Func, bool) {
+	named, ok := underlyingNamed(recv)
+	if !ok || pkg == nil || pkg.Types == nil {
+		return nil, false
+	}
+
+	typeName := named.Obj().Name()
+	for _, name := range []string{"New" + typeName, "New"} {
+		obj := pkg.Types.Scope().Lookup(name)
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok || sig.Recv() != nil || sig.Results().Len() == 0 {
+			continue
+		}
+		if resultMatches(sig.Results().At(0).Type(), named) {
+			return fn, true
+		}
+	}
+	return nil, false
+}
+
+func resultMatches(result types.Type, named *types.Named) bool {
+	if p, ok := result.(*types.Pointer); ok {
+		result = p.Elem()
+	}
+	n, ok := result.(*types.Named)
+	return ok && n.Obj() == named.Obj()
+}
@@ -3,13 +3,15 @@ package testgen
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/tools/go/packages"
+
 	"github.com/Harri200191/gitmind/internal/config"
+	gitdiff "github.com/Harri200191/gitmind/internal/diff"
 )
 
 // TestGenerator handles automatic test generation
@@ -17,47 +19,57 @@ type TestGenerator struct {
 	config config.Config
 }
 
-// FunctionInfo represents information about a function
-type FunctionInfo struct {
-	Name       string                 `json:"name"`
-	Package    string                 `json:"package"`
-	File       string                 `json:"file"`
-	Parameters []Parameter            `json:"parameters"`
-	Returns    []ReturnValue          `json:"returns"`
-	Comments   string                 `json:"comments"`
-	IsExported bool                   `json:"is_exported"`
-	Metadata   map[string]interface{} `json:"metadata"`
-}
-
-// Parameter represents a function parameter
+// Parameter represents a function parameter. goType is the resolved type
+// used to seed values and collect imports; it's nil when type resolution
+// failed for this function (see extractFunctionInfoFromAST), in which case
+// generation falls back to an untyped placeholder.
 type Parameter struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	goType types.Type
 }
 
-// ReturnValue represents a return value
+// ReturnValue represents a return value, with the same goType caveat as
+// Parameter.
 type ReturnValue struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	goType types.Type
 }
 
-// TestCase represents a generated test case
-type TestCase struct {
-	Function    string   `json:"function"`
-	TestName    string   `json:"test_name"`
-	Setup       []string `json:"setup"`
-	Inputs      []string `json:"inputs"`
-	Expected    []string `json:"expected"`
-	Assertions  []string `json:"assertions"`
-	Description string   `json:"description"`
+// FunctionInfo represents information about a function, resolved via
+// go/packages + go/types rather than reading the type strings straight off
+// the AST, so a Parameter/ReturnValue's goType is the real types.Type the
+// compiler would see.
+type FunctionInfo struct {
+	Name       string        `json:"name"`
+	Package    string        `json:"package"`
+	PkgPath    string        `json:"pkg_path"`
+	File       string        `json:"file"`
+	Parameters []Parameter   `json:"parameters"`
+	Returns    []ReturnValue `json:"returns"`
+	// Receiver is non-nil for a method, holding the receiver's name and
+	// resolved type.
+	Receiver   *Parameter             `json:"receiver,omitempty"`
+	Comments   string                 `json:"comments"`
+	IsExported bool                   `json:"is_exported"`
+	Metadata   map[string]interface{} `json:"metadata"`
+
+	// pkg is the loaded package this function was resolved against. It
+	// backs constructor discovery and import collection in GenerateTests;
+	// nil when type resolution failed.
+	pkg *packages.Package
 }
 
-// TestFile represents a complete test file
+// TestFile represents a complete generated test file.
 type TestFile struct {
-	Package   string     `json:"package"`
-	Imports   []string   `json:"imports"`
-	TestCases []TestCase `json:"test_cases"`
-	Content   string     `json:"content"`
+	Package string   `json:"package"`
+	Imports []string `json:"imports"`
+	Content string   `json:"content"`
+	// FuzzCorpus holds each generated FuzzX target's seed corpus, in the
+	// "go test fuzz v1" format WriteTestFiles persists under
+	// TestGeneration.Fuzz.CorpusDir/FuzzX/.
+	FuzzCorpus map[string][]string `json:"-"`
 }
 
 // New creates a new test generator
@@ -71,15 +83,12 @@ func (tg *TestGenerator) AnalyzeChangedFunctions(diff string) ([]FunctionInfo, e
 		return nil, nil
 	}
 
-	// Parse the diff to get changed files
 	changedFiles := tg.extractChangedGoFiles(diff)
 
 	var functions []FunctionInfo
-
 	for _, file := range changedFiles {
 		fileFunctions, err := tg.analyzeFunctionsInFile(file, diff)
 		if err != nil {
-			// Log error but continue with other files
 			fmt.Printf("Error analyzing file %s: %v\n", file, err)
 			continue
 		}
@@ -89,7 +98,8 @@ func (tg *TestGenerator) AnalyzeChangedFunctions(diff string) ([]FunctionInfo, e
 	return functions, nil
 }
 
-// GenerateTests creates test cases for the given functions
+// GenerateTests creates a table-driven test file per package for the given
+// functions.
 func (tg *TestGenerator) GenerateTests(functions []FunctionInfo) (map[string]TestFile, error) {
 	if !tg.config.TestGeneration.Enabled {
 		return nil, nil
@@ -97,15 +107,11 @@ func (tg *TestGenerator) GenerateTests(functions []FunctionInfo) (map[string]Tes
 
 	testFiles := make(map[string]TestFile)
 
-	// Group functions by package
-	packageFunctions := tg.groupFunctionsByPackage(functions)
-
-	for pkg, pkgFunctions := range packageFunctions {
+	for pkg, pkgFunctions := range tg.groupFunctionsByPackage(functions) {
 		testFile, err := tg.generateTestFile(pkg, pkgFunctions)
 		if err != nil {
 			return nil, fmt.Errorf("error generating tests for package %s: %v", pkg, err)
 		}
-
 		testFiles[pkg] = testFile
 	}
 
@@ -117,22 +123,22 @@ func (tg *TestGenerator) WriteTestFiles(testFiles map[string]TestFile) error {
 	for pkg, testFile := range testFiles {
 		outputPath := tg.getTestFilePath(pkg)
 
-		// Ensure directory exists
 		dir := filepath.Dir(outputPath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("error creating directory %s: %v", dir, err)
 		}
 
-		// Write test file
 		if err := os.WriteFile(outputPath, []byte(testFile.Content), 0644); err != nil {
 			return fmt.Errorf("error writing test file %s: %v", outputPath, err)
 		}
 
 		fmt.Printf("Generated test file: %s\n", outputPath)
 
-		// Auto-stage if configured
+		if err := tg.writeFuzzCorpus(testFile.FuzzCorpus); err != nil {
+			return err
+		}
+
 		if tg.config.TestGeneration.AutoStage {
-			// This would need git integration
 			fmt.Printf("Auto-staging test file: %s\n", outputPath)
 		}
 	}
@@ -140,527 +146,256 @@ func (tg *TestGenerator) WriteTestFiles(testFiles map[string]TestFile) error {
 	return nil
 }
 
-// extractChangedGoFiles gets Go files from the diff
-func (tg *TestGenerator) extractChangedGoFiles(diff string) []string {
-	var files []string
-	lines := strings.Split(diff, "\n")
+// writeFuzzCorpus persists each FuzzX target's seed corpus as
+// CorpusDir/FuzzX/seedN, the on-disk layout `go test -fuzz=FuzzX` reads
+// from automatically alongside the f.Add-seeded in-memory corpus.
+func (tg *TestGenerator) writeFuzzCorpus(corpus map[string][]string) error {
+	corpusDir := tg.config.TestGeneration.Fuzz.CorpusDir
+	if corpusDir == "" {
+		corpusDir = "testdata/fuzz"
+	}
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, "+++ b/") {
-			file := strings.TrimPrefix(line, "+++ b/")
-			if strings.HasSuffix(file, ".go") && !strings.HasSuffix(file, "_test.go") {
-				files = append(files, file)
+	for fuzzName, seeds := range corpus {
+		dir := filepath.Join(corpusDir, fuzzName)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("error creating fuzz corpus directory %s: %v", dir, err)
+		}
+		for i, seed := range seeds {
+			path := filepath.Join(dir, fmt.Sprintf("seed%d", i+1))
+			if err := os.WriteFile(path, []byte(seed), 0644); err != nil {
+				return fmt.Errorf("error writing fuzz corpus file %s: %v", path, err)
 			}
 		}
 	}
 
-	return files
+	return nil
 }
 
-// analyzeFunctionsInFile parses a Go file and extracts function information
-func (tg *TestGenerator) analyzeFunctionsInFile(filename, diff string) ([]FunctionInfo, error) {
-	// Read the file
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse the file
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, content, parser.ParseComments)
+// extractChangedGoFiles gets non-test Go files from the diff, via the
+// shared diff parser rather than a bespoke "+++ b/" scan.
+func (tg *TestGenerator) extractChangedGoFiles(diff string) []string {
+	parsed, err := gitdiff.Parse(diff)
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	var functions []FunctionInfo
-
-	// Extract changed line numbers from diff
-	changedLines := tg.extractChangedLines(filename, diff)
-
-	// Walk the AST to find functions
-	ast.Inspect(node, func(n ast.Node) bool {
-		switch fn := n.(type) {
-		case *ast.FuncDecl:
-			if fn.Name.IsExported() || tg.shouldIncludePrivateFunction(fn.Name.Name) {
-				pos := fset.Position(fn.Pos())
-
-				// Check if this function was modified
-				if tg.isFunctionChanged(pos.Line, changedLines) {
-					funcInfo := tg.extractFunctionInfo(fn, node.Name.Name, filename, fset)
-					functions = append(functions, funcInfo)
-				}
-			}
+	var files []string
+	for _, fd := range parsed {
+		path := fd.Path()
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			files = append(files, path)
 		}
-		return true
-	})
-
-	return functions, nil
+	}
+	return files
 }
 
-// extractFunctionInfo creates FunctionInfo from AST node
-func (tg *TestGenerator) extractFunctionInfo(fn *ast.FuncDecl, packageName, filename string, fset *token.FileSet) FunctionInfo {
-	funcInfo := FunctionInfo{
-		Name:       fn.Name.Name,
-		Package:    packageName,
-		File:       filename,
-		IsExported: fn.Name.IsExported(),
-		Metadata:   make(map[string]interface{}),
+// extractChangedLines returns the set of post-image line numbers diff
+// actually added in filename, using gitdiff.Parse's exact hunk accounting
+// instead of counting every "+"/"-" line in the whole patch.
+func (tg *TestGenerator) extractChangedLines(filename, diff string) map[int]bool {
+	parsed, err := gitdiff.Parse(diff)
+	if err != nil {
+		return nil
 	}
 
-	// Extract parameters
-	if fn.Type.Params != nil {
-		for _, field := range fn.Type.Params.List {
-			paramType := tg.typeToString(field.Type)
-			for _, name := range field.Names {
-				funcInfo.Parameters = append(funcInfo.Parameters, Parameter{
-					Name: name.Name,
-					Type: paramType,
-				})
-			}
+	changedLines := make(map[int]bool)
+	for _, fd := range parsed {
+		if fd.Path() != filename {
+			continue
 		}
-	}
-
-	// Extract return values
-	if fn.Type.Results != nil {
-		for _, field := range fn.Type.Results.List {
-			returnType := tg.typeToString(field.Type)
-			if len(field.Names) > 0 {
-				for _, name := range field.Names {
-					funcInfo.Returns = append(funcInfo.Returns, ReturnValue{
-						Name: name.Name,
-						Type: returnType,
-					})
-				}
-			} else {
-				funcInfo.Returns = append(funcInfo.Returns, ReturnValue{
-					Type: returnType,
-				})
-			}
+		for _, line := range fd.AddedLines() {
+			changedLines[line.NewLineNo] = true
 		}
 	}
-
-	// Extract comments
-	if fn.Doc != nil {
-		funcInfo.Comments = fn.Doc.Text()
-	}
-
-	return funcInfo
+	return changedLines
 }
 
-// generateTestFile creates a complete test file for a package
-func (tg *TestGenerator) generateTestFile(pkg string, functions []FunctionInfo) (TestFile, error) {
-	testFile := TestFile{
-		Package: pkg + "_test",
-		Imports: []string{
-			"testing",
-		},
-	}
-
-	// Add package import if testing external package
-	if pkg != "main" {
-		testFile.Imports = append(testFile.Imports, fmt.Sprintf("\"%s\"", pkg))
+// isFunctionChanged reports whether any line in [start, end] was added by
+// the diff.
+func (tg *TestGenerator) isFunctionChanged(start, end int, changedLines map[int]bool) bool {
+	if len(changedLines) == 0 {
+		return false
 	}
-
-	// Generate test cases for each function
-	for _, fn := range functions {
-		testCases := tg.generateTestCases(fn)
-		testFile.TestCases = append(testFile.TestCases, testCases...)
+	for line := start; line <= end; line++ {
+		if changedLines[line] {
+			return true
+		}
 	}
-
-	// Generate the file content
-	content := tg.buildTestFileContent(testFile)
-	testFile.Content = content
-
-	return testFile, nil
+	return false
 }
 
-// generateTestCases creates test cases for a function
-func (tg *TestGenerator) generateTestCases(fn FunctionInfo) []TestCase {
-	var testCases []TestCase
-
-	// Generate basic test case
-	basicTest := TestCase{
-		Function:    fn.Name,
-		TestName:    fmt.Sprintf("Test%s", fn.Name),
-		Description: fmt.Sprintf("Test basic functionality of %s", fn.Name),
-	}
-
-	// Generate setup code
-	basicTest.Setup = tg.generateSetup(fn)
-
-	// Generate input values
-	basicTest.Inputs = tg.generateInputs(fn)
-
-	// Generate expected values
-	basicTest.Expected = tg.generateExpected(fn)
-
-	// Generate assertions
-	basicTest.Assertions = tg.generateAssertions(fn)
-
-	testCases = append(testCases, basicTest)
-
-	// Generate edge case tests if applicable
-	if tg.shouldGenerateEdgeCases(fn) {
-		edgeTest := TestCase{
-			Function:    fn.Name,
-			TestName:    fmt.Sprintf("Test%s_EdgeCases", fn.Name),
-			Description: fmt.Sprintf("Test edge cases of %s", fn.Name),
-		}
+func (tg *TestGenerator) shouldIncludePrivateFunction(name string) bool {
+	// Include private functions for comprehensive testing
+	return true
+}
 
-		edgeTest.Setup = tg.generateEdgeCaseSetup(fn)
-		edgeTest.Inputs = tg.generateEdgeCaseInputs(fn)
-		edgeTest.Expected = tg.generateEdgeCaseExpected(fn)
-		edgeTest.Assertions = tg.generateAssertions(fn)
+// analyzeFunctionsInFile loads filename's package with go/packages and
+// extracts FunctionInfo for every changed, testable function.
+func (tg *TestGenerator) analyzeFunctionsInFile(filename, diff string) ([]FunctionInfo, error) {
+	changedLines := tg.extractChangedLines(filename, diff)
 
-		testCases = append(testCases, edgeTest)
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate error case tests
-	if tg.hasErrorReturn(fn) {
-		errorTest := TestCase{
-			Function:    fn.Name,
-			TestName:    fmt.Sprintf("Test%s_Error", fn.Name),
-			Description: fmt.Sprintf("Test error handling of %s", fn.Name),
-		}
-
-		errorTest.Setup = tg.generateErrorSetup(fn)
-		errorTest.Inputs = tg.generateErrorInputs(fn)
-		errorTest.Expected = tg.generateErrorExpected(fn)
-		errorTest.Assertions = tg.generateErrorAssertions(fn)
-
-		testCases = append(testCases, errorTest)
+	pkg, file, err := tg.loadFile(abs)
+	if err != nil {
+		// Type resolution isn't available (e.g. the package doesn't load
+		// cleanly) -- fall back to a plain AST parse so callers still get
+		// names, at the cost of untyped placeholders in generated tests.
+		return tg.analyzeFunctionsFromAST(filename, changedLines)
 	}
 
-	return testCases
-}
-
-// Helper methods
-func (tg *TestGenerator) extractChangedLines(filename, diff string) map[int]bool {
-	changedLines := make(map[int]bool)
-	lines := strings.Split(diff, "\n")
-
-	inFile := false
-	for _, line := range lines {
-		if strings.HasPrefix(line, "+++ b/") {
-			file := strings.TrimPrefix(line, "+++ b/")
-			inFile = (file == filename)
-			continue
-		}
-
-		if !inFile {
-			continue
+	var functions []FunctionInfo
+	ast.Inspect(file, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok {
+			return true
 		}
-
-		if strings.HasPrefix(line, "@@") {
-			// Parse hunk header for line numbers
-			// This is a simplified version
-			continue
+		if !fn.Name.IsExported() && !tg.shouldIncludePrivateFunction(fn.Name.Name) {
+			return true
 		}
 
-		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
-			// Mark as changed (simplified)
-			changedLines[len(changedLines)] = true
+		start := pkg.Fset.Position(fn.Pos()).Line
+		end := pkg.Fset.Position(fn.End()).Line
+		if !tg.isFunctionChanged(start, end, changedLines) {
+			return true
 		}
-	}
 
-	return changedLines
-}
-
-func (tg *TestGenerator) isFunctionChanged(line int, changedLines map[int]bool) bool {
-	// Simplified check - in real implementation, would need better line tracking
-	return len(changedLines) > 0
-}
+		functions = append(functions, tg.extractFunctionInfo(fn, pkg, filename))
+		return true
+	})
 
-func (tg *TestGenerator) shouldIncludePrivateFunction(name string) bool {
-	// Include private functions for comprehensive testing
-	return true
+	return functions, nil
 }
 
-func (tg *TestGenerator) typeToString(expr ast.Expr) string {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return t.Name
-	case *ast.SelectorExpr:
-		return tg.typeToString(t.X) + "." + t.Sel.Name
-	case *ast.StarExpr:
-		return "*" + tg.typeToString(t.X)
-	case *ast.ArrayType:
-		return "[]" + tg.typeToString(t.Elt)
-	default:
-		return "interface{}"
+// loadFile loads the go/packages.Package containing absPath, along with
+// that file's own *ast.File from the package's parsed syntax.
+func (tg *TestGenerator) loadFile(absPath string) (*packages.Package, *ast.File, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports,
+		Dir: filepath.Dir(absPath),
 	}
-}
 
-func (tg *TestGenerator) groupFunctionsByPackage(functions []FunctionInfo) map[string][]FunctionInfo {
-	groups := make(map[string][]FunctionInfo)
-	for _, fn := range functions {
-		groups[fn.Package] = append(groups[fn.Package], fn)
+	pkgs, err := packages.Load(cfg, "file="+absPath)
+	if err != nil {
+		return nil, nil, err
 	}
-	return groups
-}
-
-func (tg *TestGenerator) getTestFilePath(pkg string) string {
-	outputDir := tg.config.TestGeneration.OutputDir
-	if outputDir == "" {
-		outputDir = "."
+	if len(pkgs) == 0 {
+		return nil, nil, fmt.Errorf("no package found for %s", absPath)
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, nil, fmt.Errorf("package for %s has errors: %v", absPath, pkg.Errors[0])
 	}
-	return filepath.Join(outputDir, pkg+"_test.go")
-}
 
-func (tg *TestGenerator) generateSetup(fn FunctionInfo) []string {
-	var setup []string
-
-	// Basic setup based on parameters
-	for _, param := range fn.Parameters {
-		switch param.Type {
-		case "string":
-			setup = append(setup, fmt.Sprintf("%s := \"test\"", param.Name))
-		case "int", "int64", "int32":
-			setup = append(setup, fmt.Sprintf("%s := 42", param.Name))
-		case "bool":
-			setup = append(setup, fmt.Sprintf("%s := true", param.Name))
-		default:
-			setup = append(setup, fmt.Sprintf("// TODO: setup %s of type %s", param.Name, param.Type))
+	for _, f := range pkg.Syntax {
+		if pkg.Fset.Position(f.Pos()).Filename == absPath {
+			return pkg, f, nil
 		}
 	}
-
-	return setup
+	return nil, nil, fmt.Errorf("%s not found in its own package's syntax", absPath)
 }
 
-func (tg *TestGenerator) generateInputs(fn FunctionInfo) []string {
-	var inputs []string
-	for _, param := range fn.Parameters {
-		inputs = append(inputs, param.Name)
+// extractFunctionInfo builds FunctionInfo from fn's resolved *types.Func,
+// falling back to extractFunctionInfoFromAST if the package's type info
+// doesn't have an entry for it (a parse-only file, a build-tagged file the
+// driver skipped, ...).
+func (tg *TestGenerator) extractFunctionInfo(fn *ast.FuncDecl, pkg *packages.Package, filename string) FunctionInfo {
+	info := FunctionInfo{
+		Name:       fn.Name.Name,
+		Package:    pkg.Name,
+		PkgPath:    pkg.PkgPath,
+		File:       filename,
+		IsExported: fn.Name.IsExported(),
+		Metadata:   make(map[string]interface{}),
+		pkg:        pkg,
 	}
-	return inputs
-}
-
-func (tg *TestGenerator) generateExpected(fn FunctionInfo) []string {
-	var expected []string
-
-	for i, ret := range fn.Returns {
-		switch ret.Type {
-		case "string":
-			expected = append(expected, fmt.Sprintf("expected%d := \"expected\"", i))
-		case "int", "int64", "int32":
-			expected = append(expected, fmt.Sprintf("expected%d := 42", i))
-		case "bool":
-			expected = append(expected, fmt.Sprintf("expected%d := true", i))
-		case "error":
-			expected = append(expected, fmt.Sprintf("expected%d := error(nil)", i))
-		default:
-			expected = append(expected, fmt.Sprintf("// TODO: define expected%d of type %s", i, ret.Type))
-		}
+	if fn.Doc != nil {
+		info.Comments = fn.Doc.Text()
 	}
 
-	return expected
-}
-
-func (tg *TestGenerator) generateAssertions(fn FunctionInfo) []string {
-	var assertions []string
-
-	// Generate function call
-	var callArgs []string
-	for _, param := range fn.Parameters {
-		callArgs = append(callArgs, param.Name)
+	obj, ok := pkg.TypesInfo.Defs[fn.Name]
+	if !ok || obj == nil {
+		return tg.extractFunctionInfoFromAST(fn, info)
 	}
-
-	var resultVars []string
-	for i := range fn.Returns {
-		resultVars = append(resultVars, fmt.Sprintf("result%d", i))
+	funcObj, ok := obj.(*types.Func)
+	if !ok {
+		return tg.extractFunctionInfoFromAST(fn, info)
 	}
-
-	if len(resultVars) > 0 {
-		call := fmt.Sprintf("%s := %s(%s)",
-			strings.Join(resultVars, ", "),
-			fn.Name,
-			strings.Join(callArgs, ", "))
-		assertions = append(assertions, call)
-
-		// Generate assertions for each return value
-		for i, ret := range fn.Returns {
-			if ret.Type == "error" {
-				assertions = append(assertions, fmt.Sprintf("if result%d != nil {", i))
-				assertions = append(assertions, fmt.Sprintf("\tt.Errorf(\"Unexpected error: %%v\", result%d)", i))
-				assertions = append(assertions, "}")
-			} else {
-				assertions = append(assertions, fmt.Sprintf("if result%d != expected%d {", i, i))
-				assertions = append(assertions, fmt.Sprintf("\tt.Errorf(\"Expected %%v, got %%v\", expected%d, result%d)", i, i))
-				assertions = append(assertions, "}")
-			}
-		}
+	sig, ok := funcObj.Type().(*types.Signature)
+	if !ok {
+		return tg.extractFunctionInfoFromAST(fn, info)
 	}
 
-	return assertions
-}
+	q := types.RelativeTo(pkg.Types)
 
-func (tg *TestGenerator) shouldGenerateEdgeCases(fn FunctionInfo) bool {
-	// Generate edge cases for functions with numeric or string parameters
-	for _, param := range fn.Parameters {
-		if strings.Contains(param.Type, "int") || param.Type == "string" {
-			return true
+	if recv := sig.Recv(); recv != nil {
+		name := recv.Name()
+		if name == "" || name == "_" {
+			name = "recv"
 		}
-	}
-	return false
-}
-
-func (tg *TestGenerator) hasErrorReturn(fn FunctionInfo) bool {
-	for _, ret := range fn.Returns {
-		if ret.Type == "error" {
-			return true
+		info.Receiver = &Parameter{
+			Name:   name,
+			Type:   types.TypeString(recv.Type(), q),
+			goType: recv.Type(),
 		}
 	}
-	return false
-}
 
-func (tg *TestGenerator) generateEdgeCaseSetup(fn FunctionInfo) []string {
-	var setup []string
-
-	for _, param := range fn.Parameters {
-		switch param.Type {
-		case "string":
-			setup = append(setup, fmt.Sprintf("%s := \"\"", param.Name)) // empty string
-		case "int", "int64", "int32":
-			setup = append(setup, fmt.Sprintf("%s := 0", param.Name)) // zero value
-		default:
-			setup = append(setup, fmt.Sprintf("// TODO: edge case setup for %s", param.Name))
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		p := params.At(i)
+		name := p.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
 		}
-	}
-
-	return setup
-}
-
-func (tg *TestGenerator) generateEdgeCaseInputs(fn FunctionInfo) []string {
-	return tg.generateInputs(fn) // Same as regular inputs for now
-}
-
-func (tg *TestGenerator) generateEdgeCaseExpected(fn FunctionInfo) []string {
-	return tg.generateExpected(fn) // Same as regular expected for now
-}
-
-func (tg *TestGenerator) generateErrorSetup(fn FunctionInfo) []string {
-	var setup []string
-
-	for _, param := range fn.Parameters {
-		switch param.Type {
-		case "string":
-			setup = append(setup, fmt.Sprintf("%s := \"invalid\"", param.Name))
-		default:
-			setup = append(setup, fmt.Sprintf("// TODO: error case setup for %s", param.Name))
-		}
-	}
-
-	return setup
-}
-
-func (tg *TestGenerator) generateErrorInputs(fn FunctionInfo) []string {
-	return tg.generateInputs(fn)
-}
-
-func (tg *TestGenerator) generateErrorExpected(fn FunctionInfo) []string {
-	var expected []string
-
-	for i, ret := range fn.Returns {
-		if ret.Type == "error" {
-			expected = append(expected, fmt.Sprintf("expectedErr%d := \"some error\"", i))
-		} else {
-			expected = append(expected, fmt.Sprintf("// TODO: define expected%d for error case", i))
+		info.Parameters = append(info.Parameters, Parameter{
+			Name:   name,
+			Type:   types.TypeString(p.Type(), q),
+			goType: p.Type(),
+		})
+	}
+
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		r := results.At(i)
+		name := r.Name()
+		if name == "" {
+			name = fmt.Sprintf("ret%d", i)
 		}
+		info.Returns = append(info.Returns, ReturnValue{
+			Name:   name,
+			Type:   types.TypeString(r.Type(), q),
+			goType: r.Type(),
+		})
 	}
 
-	return expected
+	return info
 }
 
-func (tg *TestGenerator) generateErrorAssertions(fn FunctionInfo) []string {
-	var assertions []string
-
-	// Similar to regular assertions but expecting errors
-	var callArgs []string
-	for _, param := range fn.Parameters {
-		callArgs = append(callArgs, param.Name)
-	}
-
-	var resultVars []string
-	for i := range fn.Returns {
-		resultVars = append(resultVars, fmt.Sprintf("result%d", i))
-	}
-
-	if len(resultVars) > 0 {
-		call := fmt.Sprintf("%s := %s(%s)",
-			strings.Join(resultVars, ", "),
-			fn.Name,
-			strings.Join(callArgs, ", "))
-		assertions = append(assertions, call)
-
-		// Check for expected errors
-		for i, ret := range fn.Returns {
-			if ret.Type == "error" {
-				assertions = append(assertions, fmt.Sprintf("if result%d == nil {", i))
-				assertions = append(assertions, "\tt.Error(\"Expected error but got nil\")")
-				assertions = append(assertions, "}")
-			}
-		}
+func (tg *TestGenerator) groupFunctionsByPackage(functions []FunctionInfo) map[string][]FunctionInfo {
+	groups := make(map[string][]FunctionInfo)
+	for _, fn := range functions {
+		groups[fn.Package] = append(groups[fn.Package], fn)
 	}
-
-	return assertions
+	return groups
 }
 
-func (tg *TestGenerator) buildTestFileContent(testFile TestFile) string {
-	var content strings.Builder
-
-	// Package declaration
-	content.WriteString(fmt.Sprintf("package %s\n\n", testFile.Package))
-
-	// Imports
-	content.WriteString("import (\n")
-	for _, imp := range testFile.Imports {
-		content.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
-	}
-	content.WriteString(")\n\n")
-
-	// Test functions
-	for _, testCase := range testFile.TestCases {
-		content.WriteString(tg.buildTestFunction(testCase))
-		content.WriteString("\n")
+func (tg *TestGenerator) getTestFilePath(pkg string) string {
+	outputDir := tg.config.TestGeneration.OutputDir
+	if outputDir == "" {
+		outputDir = "."
 	}
-
-	return content.String()
+	return filepath.Join(outputDir, pkg+"_test.go")
 }
 
-func (tg *TestGenerator) buildTestFunction(testCase TestCase) string {
-	var content strings.Builder
-
-	// Function signature
-	content.WriteString(fmt.Sprintf("func %s(t *testing.T) {\n", testCase.TestName))
-
-	if testCase.Description != "" {
-		content.WriteString(fmt.Sprintf("\t// %s\n", testCase.Description))
-	}
-
-	// Setup
-	for _, setup := range testCase.Setup {
-		content.WriteString(fmt.Sprintf("\t%s\n", setup))
-	}
-
-	if len(testCase.Setup) > 0 {
-		content.WriteString("\n")
-	}
-
-	// Expected values
-	for _, expected := range testCase.Expected {
-		content.WriteString(fmt.Sprintf("\t%s\n", expected))
-	}
-
-	if len(testCase.Expected) > 0 {
-		content.WriteString("\n")
-	}
-
-	// Assertions
-	for _, assertion := range testCase.Assertions {
-		content.WriteString(fmt.Sprintf("\t%s\n", assertion))
-	}
-
-	content.WriteString("}\n")
-
-	return content.String()
+// generateTestFile renders one table-driven test file for pkg's functions,
+// plus a FuzzX target per eligible function when fuzz generation is
+// enabled and the module's go.mod declares Go 1.18 or newer (native fuzzing
+// requires it).
+func (tg *TestGenerator) generateTestFile(pkg string, functions []FunctionInfo) (TestFile, error) {
+	fuzz := tg.config.TestGeneration.Fuzz
+	return buildTestFile(pkg, functions, fuzz, fuzz.Enabled && goModSupportsFuzzing())
 }
@@ -0,0 +1,29 @@
+// Package tui is cmdMultiCommit's entry point for reviewing and
+// reshuffling multi-commit proposals interactively -- the three-pane
+// proposal-list/files/diff-preview panel this package name describes is
+// not implemented here yet, since it needs a real terminal UI library
+// (tcell or gocui, see the commented require in go.mod) this tree can't
+// vendor. Until one is, Run delegates to internal/ui's line-oriented
+// ProposalEditor, which already drives the same reshaping model (move,
+// squash, split, reorder, edit message, drop hunks) a panel-backed
+// implementation would. cmd/gitmind depends on this package rather than
+// internal/ui directly, so vendoring a real TUI later only touches this
+// file.
+package tui
+
+import (
+	"github.com/Harri200191/gitmind/internal/splitter"
+	"github.com/Harri200191/gitmind/internal/ui"
+)
+
+// Run reviews and reshuffles proposals interactively, returning the
+// possibly-reshaped set to execute, or nil if the user cancelled.
+func Run(proposals []splitter.CommitProposal) ([]splitter.CommitProposal, error) {
+	return ui.NewProposalEditor(proposals).Run()
+}
+
+// PanelStatus describes whether Run drives a real three-pane panel or is
+// still delegating to internal/ui's line-oriented editor, for cmdDoctor to
+// report -- see the package doc comment for why. It's a string rather than
+// a bool so the message is the single source of truth for what's missing.
+const PanelStatus = "line-oriented editor (internal/ui), not a three-pane panel -- needs tcell/gocui vendored"
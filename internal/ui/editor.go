@@ -0,0 +1,440 @@
+// Package ui provides the interactive editor InteractiveMultiCommit hands
+// control to when a user wants to reshape a multi-commit proposal before
+// it's executed -- reordering, squashing, splitting, or moving individual
+// hunks between proposals, lazygit-rebase-panel style.
+//
+// The real thing this should become is a proper terminal UI (tcell or
+// gocui, neither vendored here -- see the commented require in go.mod)
+// with a proposal list pane, a files-in-proposal pane, and a live diff
+// preview pane. Until one of those is vendored, ProposalEditor drives the
+// same model -- []splitter.CommitProposal plus each proposal's per-file
+// []splitter.Hunk -- through a line-oriented command loop on stdin/stdout,
+// the same style InteractiveMultiCommit's own y/n prompts already use.
+// Swapping in a real TUI later only touches this package's Run method;
+// internal/tui already calls Run as if it were panel-backed. `gitmind
+// doctor`'s "Deferred library integrations" section (and tui.PanelStatus)
+// report this gap at runtime, so it isn't only discoverable by reading
+// this comment.
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Harri200191/gitmind/internal/splitter"
+)
+
+// ProposalEditor drives the interactive reshaping of a set of
+// CommitProposals before they're handed to splitter.ExecuteMultiCommit.
+type ProposalEditor struct {
+	proposals []splitter.CommitProposal
+	cursor    int
+
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewProposalEditor returns an editor seeded with a copy of proposals --
+// Run's reshaping never mutates the caller's slice.
+func NewProposalEditor(proposals []splitter.CommitProposal) *ProposalEditor {
+	return &ProposalEditor{
+		proposals: append([]splitter.CommitProposal(nil), proposals...),
+		in:        bufio.NewReader(os.Stdin),
+		out:       os.Stdout,
+	}
+}
+
+// hunkRef locates one hunk within a proposal's Changes, so commands can
+// address it by the flat index render prints alongside it.
+type hunkRef struct {
+	changeIdx int
+	hunkIdx   int
+	hunk      splitter.Hunk
+}
+
+// Run drives the command loop until the user accepts the proposals
+// (Enter), cancels (q or EOF), returning the possibly-reshaped proposals,
+// or nil if the user cancelled.
+func (e *ProposalEditor) Run() ([]splitter.CommitProposal, error) {
+	if len(e.proposals) == 0 {
+		return e.proposals, nil
+	}
+
+	for {
+		e.render()
+		fmt.Fprint(e.out, "\n[j/k move  s squash  x split  e edit message  m move hunk  d drop hunks  r reorder  Enter accept  q cancel]: ")
+
+		line, err := e.in.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		switch cmd := strings.TrimSpace(line); cmd {
+		case "":
+			return e.proposals, nil
+		case "q":
+			return nil, nil
+		case "j":
+			e.move(1)
+		case "k":
+			e.move(-1)
+		case "s":
+			e.squash()
+		case "x":
+			if err := e.split(); err != nil {
+				fmt.Fprintf(e.out, "split failed: %v\n", err)
+			}
+		case "e":
+			if err := e.editMessage(); err != nil {
+				fmt.Fprintf(e.out, "edit failed: %v\n", err)
+			}
+		case "m":
+			if err := e.moveHunk(); err != nil {
+				fmt.Fprintf(e.out, "move failed: %v\n", err)
+			}
+		case "d":
+			if err := e.dropHunks(); err != nil {
+				fmt.Fprintf(e.out, "drop failed: %v\n", err)
+			}
+		case "r":
+			if err := e.reorder(); err != nil {
+				fmt.Fprintf(e.out, "reorder failed: %v\n", err)
+			}
+		default:
+			fmt.Fprintf(e.out, "unrecognized command %q\n", cmd)
+		}
+	}
+}
+
+// render prints the left pane (the proposal list, cursor marked) followed
+// by the right pane: the selected proposal's hunks, each labeled with the
+// flat index s/x/m/d prompts for.
+func (e *ProposalEditor) render() {
+	fmt.Fprintln(e.out)
+	for i, p := range e.proposals {
+		marker := "  "
+		if i == e.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(e.out, "%s%d. %s  [%s]\n", marker, i+1, firstLine(p.Message), strings.Join(p.Files, ", "))
+	}
+
+	fmt.Fprintln(e.out, "\n--- hunks in selected proposal ---")
+	for i, ref := range e.hunkRefs(e.cursor) {
+		fmt.Fprintf(e.out, "  [%d] %s:%d-%d\n", i, ref.hunk.File, ref.hunk.StartLine, ref.hunk.EndLine)
+		fmt.Fprint(e.out, indent(ref.hunk.Content))
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func indent(content string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		fmt.Fprintf(&b, "      %s\n", line)
+	}
+	return b.String()
+}
+
+// hunkRefs flattens proposals[idx].Changes into an ordered list of
+// addressable hunks.
+func (e *ProposalEditor) hunkRefs(idx int) []hunkRef {
+	var refs []hunkRef
+	for ci, change := range e.proposals[idx].Changes {
+		for hi, h := range change.Hunks {
+			refs = append(refs, hunkRef{changeIdx: ci, hunkIdx: hi, hunk: h})
+		}
+	}
+	return refs
+}
+
+func (e *ProposalEditor) move(delta int) {
+	next := e.cursor + delta
+	if next < 0 || next >= len(e.proposals) {
+		return
+	}
+	e.cursor = next
+}
+
+// squash merges the selected proposal into the one above it, keeping the
+// earlier proposal's message -- the later proposal's changes simply
+// become part of it.
+func (e *ProposalEditor) squash() {
+	if e.cursor == 0 {
+		fmt.Fprintln(e.out, "nothing above to squash into")
+		return
+	}
+	prev := e.cursor - 1
+	target := &e.proposals[prev]
+	target.Changes = append(target.Changes, e.proposals[e.cursor].Changes...)
+	target.Files = filesFromChanges(target.Changes)
+
+	e.proposals = append(e.proposals[:e.cursor], e.proposals[e.cursor+1:]...)
+	e.cursor = prev
+}
+
+// split reads a comma-separated list of hunk indices (as printed by
+// render) from the selected proposal and moves them into a new proposal
+// inserted immediately after it.
+func (e *ProposalEditor) split() error {
+	refs := e.hunkRefs(e.cursor)
+	if len(refs) == 0 {
+		return fmt.Errorf("selected proposal has no hunks")
+	}
+
+	fmt.Fprint(e.out, "hunk indices to move into a new proposal (comma separated): ")
+	picked, err := e.readIndices(len(refs))
+	if err != nil {
+		return err
+	}
+	if len(picked) == 0 {
+		return fmt.Errorf("no hunks selected")
+	}
+
+	fmt.Fprint(e.out, "message for the new proposal: ")
+	message, err := e.readLine()
+	if err != nil {
+		return err
+	}
+
+	current := e.proposals[e.cursor]
+	kept, moved := partitionChanges(current.Changes, refs, picked)
+
+	current.Changes = kept
+	current.Files = filesFromChanges(kept)
+
+	newProposal := splitter.CommitProposal{
+		Message: message,
+		Changes: moved,
+		Files:   filesFromChanges(moved),
+	}
+
+	e.proposals[e.cursor] = current
+	e.proposals = append(e.proposals[:e.cursor+1], append([]splitter.CommitProposal{newProposal}, e.proposals[e.cursor+1:]...)...)
+	return nil
+}
+
+// moveHunk reads one hunk index from the selected proposal and a
+// destination proposal number, then relocates that single hunk.
+func (e *ProposalEditor) moveHunk() error {
+	refs := e.hunkRefs(e.cursor)
+	if len(refs) == 0 {
+		return fmt.Errorf("selected proposal has no hunks")
+	}
+
+	fmt.Fprint(e.out, "hunk index to move: ")
+	idx, err := e.readIndex(len(refs))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.out, "destination proposal (1-%d): ", len(e.proposals))
+	dest, err := e.readIndex(len(e.proposals))
+	if err != nil {
+		return err
+	}
+	if dest == e.cursor {
+		return fmt.Errorf("already in that proposal")
+	}
+
+	current := e.proposals[e.cursor]
+	kept, moved := partitionChanges(current.Changes, refs, map[int]bool{idx: true})
+	current.Changes = kept
+	current.Files = filesFromChanges(kept)
+	e.proposals[e.cursor] = current
+
+	target := &e.proposals[dest]
+	target.Changes = mergeChanges(target.Changes, moved)
+	target.Files = filesFromChanges(target.Changes)
+	return nil
+}
+
+// dropHunks reads a comma-separated list of hunk indices from the
+// selected proposal and discards them entirely -- they won't be part of
+// any commit.
+func (e *ProposalEditor) dropHunks() error {
+	refs := e.hunkRefs(e.cursor)
+	if len(refs) == 0 {
+		return fmt.Errorf("selected proposal has no hunks")
+	}
+
+	fmt.Fprint(e.out, "hunk indices to drop (comma separated): ")
+	picked, err := e.readIndices(len(refs))
+	if err != nil {
+		return err
+	}
+	if len(picked) == 0 {
+		return fmt.Errorf("no hunks selected")
+	}
+
+	current := e.proposals[e.cursor]
+	kept, _ := partitionChanges(current.Changes, refs, picked)
+	current.Changes = kept
+	current.Files = filesFromChanges(kept)
+	e.proposals[e.cursor] = current
+	return nil
+}
+
+// reorder moves the selected proposal to a new position in the list --
+// unlike move, which only shifts the cursor, this changes the order
+// ExecuteMultiCommit will create the commits in.
+func (e *ProposalEditor) reorder() error {
+	fmt.Fprintf(e.out, "move proposal %d to position (1-%d): ", e.cursor+1, len(e.proposals))
+	line, err := e.readLine()
+	if err != nil {
+		return err
+	}
+	pos, err := strconv.Atoi(line)
+	if err != nil || pos < 1 || pos > len(e.proposals) {
+		return fmt.Errorf("invalid position %q", line)
+	}
+	dest := pos - 1
+	if dest == e.cursor {
+		return nil
+	}
+
+	moving := e.proposals[e.cursor]
+	e.proposals = append(e.proposals[:e.cursor], e.proposals[e.cursor+1:]...)
+
+	if dest > e.cursor {
+		dest--
+	}
+	e.proposals = append(e.proposals[:dest], append([]splitter.CommitProposal{moving}, e.proposals[dest:]...)...)
+	e.cursor = dest
+	return nil
+}
+
+func (e *ProposalEditor) editMessage() error {
+	fmt.Fprint(e.out, "new message: ")
+	message, err := e.readLine()
+	if err != nil {
+		return err
+	}
+	e.proposals[e.cursor].Message = message
+	return nil
+}
+
+func (e *ProposalEditor) readLine() (string, error) {
+	line, err := e.in.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (e *ProposalEditor) readIndex(count int) (int, error) {
+	line, err := e.readLine()
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %q", line)
+	}
+	if n < 0 || n >= count {
+		return 0, fmt.Errorf("index %d out of range [0,%d)", n, count)
+	}
+	return n, nil
+}
+
+func (e *ProposalEditor) readIndices(count int) (map[int]bool, error) {
+	line, err := e.readLine()
+	if err != nil {
+		return nil, err
+	}
+	picked := make(map[int]bool)
+	for _, field := range strings.Split(line, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q", field)
+		}
+		if n < 0 || n >= count {
+			return nil, fmt.Errorf("index %d out of range [0,%d)", n, count)
+		}
+		picked[n] = true
+	}
+	return picked, nil
+}
+
+// partitionChanges splits changes into (kept, moved) according to which
+// flat hunk indices in picked -- as produced by hunkRefs over the same
+// changes -- should move out. A Change left with no hunks is dropped from
+// whichever side it ended up empty on.
+func partitionChanges(changes []splitter.Change, refs []hunkRef, picked map[int]bool) (kept, moved []splitter.Change) {
+	keptHunks := make(map[int][]splitter.Hunk)
+	movedHunks := make(map[int][]splitter.Hunk)
+
+	for i, ref := range refs {
+		if picked[i] {
+			movedHunks[ref.changeIdx] = append(movedHunks[ref.changeIdx], ref.hunk)
+		} else {
+			keptHunks[ref.changeIdx] = append(keptHunks[ref.changeIdx], ref.hunk)
+		}
+	}
+
+	for ci, change := range changes {
+		if hunks := keptHunks[ci]; len(hunks) > 0 {
+			c := change
+			c.Hunks = hunks
+			kept = append(kept, c)
+		}
+		if hunks := movedHunks[ci]; len(hunks) > 0 {
+			c := change
+			c.Hunks = hunks
+			moved = append(moved, c)
+		}
+	}
+	return kept, moved
+}
+
+// mergeChanges appends extra onto base, folding a Change into an existing
+// one for the same file instead of duplicating it.
+func mergeChanges(base, extra []splitter.Change) []splitter.Change {
+	for _, add := range extra {
+		merged := false
+		for i, existing := range base {
+			if len(existing.Files) == 1 && len(add.Files) == 1 && existing.Files[0] == add.Files[0] {
+				base[i].Hunks = append(base[i].Hunks, add.Hunks...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			base = append(base, add)
+		}
+	}
+	return base
+}
+
+// filesFromChanges gets all unique files referenced across changes, in
+// first-seen order -- the same shape splitter's own cluster-to-proposal
+// conversion produces.
+func filesFromChanges(changes []splitter.Change) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, change := range changes {
+		for _, file := range change.Files {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}